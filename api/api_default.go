@@ -24,71 +24,53 @@ import (
 // DefaultAPIService DefaultAPI service
 type DefaultAPIService service
 
-type ApiV1CitiesCityidBuildingqitemsGetRequest struct {
+type ApiV1AlliancesAllianceidGetRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
-	lastid *string
-	pagesize *int32
-}
-
-func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Lastid(lastid string) ApiV1CitiesCityidBuildingqitemsGetRequest {
-	r.lastid = &lastid
-	return r
-}
-
-func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Pagesize(pagesize int32) ApiV1CitiesCityidBuildingqitemsGetRequest {
-	r.pagesize = &pagesize
-	return r
+	allianceid string
 }
 
-func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Execute() ([]V1BuildingQueueItem, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidBuildingqitemsGetExecute(r)
+func (r ApiV1AlliancesAllianceidGetRequest) Execute() (*V1Alliance, *http.Response, error) {
+	return r.ApiService.V1AlliancesAllianceidGetExecute(r)
 }
 
 /*
-V1CitiesCityidBuildingqitemsGet List building queue items.
+V1AlliancesAllianceidGet Get an alliance with its members and pending invites.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidBuildingqitemsGetRequest
+ @param allianceid
+ @return ApiV1AlliancesAllianceidGetRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsGet(ctx context.Context, cityid string) ApiV1CitiesCityidBuildingqitemsGetRequest {
-	return ApiV1CitiesCityidBuildingqitemsGetRequest{
+func (a *DefaultAPIService) V1AlliancesAllianceidGet(ctx context.Context, allianceid string) ApiV1AlliancesAllianceidGetRequest {
+	return ApiV1AlliancesAllianceidGetRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
+		allianceid: allianceid,
 	}
 }
 
 // Execute executes the request
-//  @return []V1BuildingQueueItem
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsGetExecute(r ApiV1CitiesCityidBuildingqitemsGetRequest) ([]V1BuildingQueueItem, *http.Response, error) {
+//  @return V1Alliance
+func (a *DefaultAPIService) V1AlliancesAllianceidGetExecute(r ApiV1AlliancesAllianceidGetRequest) (*V1Alliance, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  []V1BuildingQueueItem
+		localVarReturnValue  *V1Alliance
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesAllianceidGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/alliances/{allianceid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"allianceid"+"}", url.PathEscape(parameterValueToString(r.allianceid, "allianceid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.lastid != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
-	}
-	if r.pagesize != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -143,59 +125,62 @@ func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsGetExecute(r ApiV1Cities
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidBuildingqitemsItemidGetRequest struct {
+type ApiV1AlliancesAllianceidInvitesPostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
-	itemid string
+	allianceid string
+	v1AllianceInvite *V1AllianceInvite
 }
 
-func (r ApiV1CitiesCityidBuildingqitemsItemidGetRequest) Execute() (*V1BuildingQueueItem, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidBuildingqitemsItemidGetExecute(r)
+func (r ApiV1AlliancesAllianceidInvitesPostRequest) V1AllianceInvite(v1AllianceInvite V1AllianceInvite) ApiV1AlliancesAllianceidInvitesPostRequest {
+	r.v1AllianceInvite = &v1AllianceInvite
+	return r
+}
+
+func (r ApiV1AlliancesAllianceidInvitesPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1AlliancesAllianceidInvitesPostExecute(r)
 }
 
 /*
-V1CitiesCityidBuildingqitemsItemidGet Get a building queue item.
+V1AlliancesAllianceidInvitesPost Invite a player to the alliance.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @param itemid
- @return ApiV1CitiesCityidBuildingqitemsItemidGetRequest
+ @param allianceid
+ @return ApiV1AlliancesAllianceidInvitesPostRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGet(ctx context.Context, cityid string, itemid string) ApiV1CitiesCityidBuildingqitemsItemidGetRequest {
-	return ApiV1CitiesCityidBuildingqitemsItemidGetRequest{
+func (a *DefaultAPIService) V1AlliancesAllianceidInvitesPost(ctx context.Context, allianceid string) ApiV1AlliancesAllianceidInvitesPostRequest {
+	return ApiV1AlliancesAllianceidInvitesPostRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
-		itemid: itemid,
+		allianceid: allianceid,
 	}
 }
 
 // Execute executes the request
-//  @return V1BuildingQueueItem
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGetExecute(r ApiV1CitiesCityidBuildingqitemsItemidGetRequest) (*V1BuildingQueueItem, *http.Response, error) {
+func (a *DefaultAPIService) V1AlliancesAllianceidInvitesPostExecute(r ApiV1AlliancesAllianceidInvitesPostRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  *V1BuildingQueueItem
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsItemidGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesAllianceidInvitesPost")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems/{itemid}"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
-	localVarPath = strings.Replace(localVarPath, "{"+"itemid"+"}", url.PathEscape(parameterValueToString(r.itemid, "itemid")), -1)
+	localVarPath := localBasePath + "/v1/alliances/{allianceid}/invites"
+	localVarPath = strings.Replace(localVarPath, "{"+"allianceid"+"}", url.PathEscape(parameterValueToString(r.allianceid, "allianceid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.v1AllianceInvite == nil {
+		return nil, reportError("v1AllianceInvite is required and must be specified")
+	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -204,28 +189,30 @@ func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGetExecute(r ApiV1
 	}
 
 	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{"application/json"}
+	localVarHTTPHeaderAccepts := []string{}
 
 	// set Accept header
 	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.v1AllianceInvite
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -233,77 +220,59 @@ func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGetExecute(r ApiV1
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidBuildingqitemsPostRequest struct {
+type ApiV1AlliancesAllianceidJoinPostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
-	v1BuildingQueueItem *V1BuildingQueueItem
-}
-
-func (r ApiV1CitiesCityidBuildingqitemsPostRequest) V1BuildingQueueItem(v1BuildingQueueItem V1BuildingQueueItem) ApiV1CitiesCityidBuildingqitemsPostRequest {
-	r.v1BuildingQueueItem = &v1BuildingQueueItem
-	return r
+	allianceid string
 }
 
-func (r ApiV1CitiesCityidBuildingqitemsPostRequest) Execute() (*http.Response, error) {
-	return r.ApiService.V1CitiesCityidBuildingqitemsPostExecute(r)
+func (r ApiV1AlliancesAllianceidJoinPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1AlliancesAllianceidJoinPostExecute(r)
 }
 
 /*
-V1CitiesCityidBuildingqitemsPost Add a queue item.
+V1AlliancesAllianceidJoinPost Join an alliance the player was invited to.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidBuildingqitemsPostRequest
+ @param allianceid
+ @return ApiV1AlliancesAllianceidJoinPostRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPost(ctx context.Context, cityid string) ApiV1CitiesCityidBuildingqitemsPostRequest {
-	return ApiV1CitiesCityidBuildingqitemsPostRequest{
+func (a *DefaultAPIService) V1AlliancesAllianceidJoinPost(ctx context.Context, allianceid string) ApiV1AlliancesAllianceidJoinPostRequest {
+	return ApiV1AlliancesAllianceidJoinPostRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
+		allianceid: allianceid,
 	}
 }
 
 // Execute executes the request
-func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPostExecute(r ApiV1CitiesCityidBuildingqitemsPostRequest) (*http.Response, error) {
+func (a *DefaultAPIService) V1AlliancesAllianceidJoinPostExecute(r ApiV1AlliancesAllianceidJoinPostRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsPost")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesAllianceidJoinPost")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/alliances/{allianceid}/join"
+	localVarPath = strings.Replace(localVarPath, "{"+"allianceid"+"}", url.PathEscape(parameterValueToString(r.allianceid, "allianceid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.v1BuildingQueueItem == nil {
-		return nil, reportError("v1BuildingQueueItem is required and must be specified")
-	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -319,8 +288,6 @@ func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPostExecute(r ApiV1Citie
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.v1BuildingQueueItem
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -349,46 +316,46 @@ func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPostExecute(r ApiV1Citie
 	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidDeleteRequest struct {
+type ApiV1AlliancesAllianceidLeavePostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
+	allianceid string
 }
 
-func (r ApiV1CitiesCityidDeleteRequest) Execute() (*http.Response, error) {
-	return r.ApiService.V1CitiesCityidDeleteExecute(r)
+func (r ApiV1AlliancesAllianceidLeavePostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1AlliancesAllianceidLeavePostExecute(r)
 }
 
 /*
-V1CitiesCityidDelete Delete a city.
+V1AlliancesAllianceidLeavePost Leave the alliance.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidDeleteRequest
+ @param allianceid
+ @return ApiV1AlliancesAllianceidLeavePostRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidDelete(ctx context.Context, cityid string) ApiV1CitiesCityidDeleteRequest {
-	return ApiV1CitiesCityidDeleteRequest{
+func (a *DefaultAPIService) V1AlliancesAllianceidLeavePost(ctx context.Context, allianceid string) ApiV1AlliancesAllianceidLeavePostRequest {
+	return ApiV1AlliancesAllianceidLeavePostRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
+		allianceid: allianceid,
 	}
 }
 
 // Execute executes the request
-func (a *DefaultAPIService) V1CitiesCityidDeleteExecute(r ApiV1CitiesCityidDeleteRequest) (*http.Response, error) {
+func (a *DefaultAPIService) V1AlliancesAllianceidLeavePostExecute(r ApiV1AlliancesAllianceidLeavePostRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodDelete
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidDelete")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesAllianceidLeavePost")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/alliances/{allianceid}/leave"
+	localVarPath = strings.Replace(localVarPath, "{"+"allianceid"+"}", url.PathEscape(parameterValueToString(r.allianceid, "allianceid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -439,48 +406,50 @@ func (a *DefaultAPIService) V1CitiesCityidDeleteExecute(r ApiV1CitiesCityidDelet
 	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidGetRequest struct {
+type ApiV1AlliancesAllianceidMembersMemberidDeleteRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
+	allianceid string
+	memberid string
 }
 
-func (r ApiV1CitiesCityidGetRequest) Execute() (*V1City, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidGetExecute(r)
+func (r ApiV1AlliancesAllianceidMembersMemberidDeleteRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1AlliancesAllianceidMembersMemberidDeleteExecute(r)
 }
 
 /*
-V1CitiesCityidGet Get a city complete state.
+V1AlliancesAllianceidMembersMemberidDelete Kick a member from the alliance.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidGetRequest
+ @param allianceid
+ @param memberid
+ @return ApiV1AlliancesAllianceidMembersMemberidDeleteRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidGet(ctx context.Context, cityid string) ApiV1CitiesCityidGetRequest {
-	return ApiV1CitiesCityidGetRequest{
+func (a *DefaultAPIService) V1AlliancesAllianceidMembersMemberidDelete(ctx context.Context, allianceid string, memberid string) ApiV1AlliancesAllianceidMembersMemberidDeleteRequest {
+	return ApiV1AlliancesAllianceidMembersMemberidDeleteRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
+		allianceid: allianceid,
+		memberid: memberid,
 	}
 }
 
 // Execute executes the request
-//  @return V1City
-func (a *DefaultAPIService) V1CitiesCityidGetExecute(r ApiV1CitiesCityidGetRequest) (*V1City, *http.Response, error) {
+func (a *DefaultAPIService) V1AlliancesAllianceidMembersMemberidDeleteExecute(r ApiV1AlliancesAllianceidMembersMemberidDeleteRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  *V1City
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesAllianceidMembersMemberidDelete")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/alliances/{allianceid}/members/{memberid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"allianceid"+"}", url.PathEscape(parameterValueToString(r.allianceid, "allianceid")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"memberid"+"}", url.PathEscape(parameterValueToString(r.memberid, "memberid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -496,7 +465,7 @@ func (a *DefaultAPIService) V1CitiesCityidGetExecute(r ApiV1CitiesCityidGetReque
 	}
 
 	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{"application/json"}
+	localVarHTTPHeaderAccepts := []string{}
 
 	// set Accept header
 	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
@@ -505,19 +474,19 @@ func (a *DefaultAPIService) V1CitiesCityidGetExecute(r ApiV1CitiesCityidGetReque
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -525,68 +494,73 @@ func (a *DefaultAPIService) V1CitiesCityidGetExecute(r ApiV1CitiesCityidGetReque
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidInfoGetRequest struct {
+type ApiV1AlliancesGetRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
+	lastid *string
+	pagesize *int32
 }
 
-func (r ApiV1CitiesCityidInfoGetRequest) Execute() (*V1CityInfo, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidInfoGetExecute(r)
+func (r ApiV1AlliancesGetRequest) Lastid(lastid string) ApiV1AlliancesGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1AlliancesGetRequest) Pagesize(pagesize int32) ApiV1AlliancesGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1AlliancesGetRequest) Execute() ([]V1AllianceInfo, *http.Response, error) {
+	return r.ApiService.V1AlliancesGetExecute(r)
 }
 
 /*
-V1CitiesCityidInfoGet Get a city info.
+V1AlliancesGet List alliance infos.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidInfoGetRequest
+ @return ApiV1AlliancesGetRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidInfoGet(ctx context.Context, cityid string) ApiV1CitiesCityidInfoGetRequest {
-	return ApiV1CitiesCityidInfoGetRequest{
+func (a *DefaultAPIService) V1AlliancesGet(ctx context.Context) ApiV1AlliancesGetRequest {
+	return ApiV1AlliancesGetRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
 	}
 }
 
 // Execute executes the request
-//  @return V1CityInfo
-func (a *DefaultAPIService) V1CitiesCityidInfoGetExecute(r ApiV1CitiesCityidInfoGetRequest) (*V1CityInfo, *http.Response, error) {
+//  @return []V1AllianceInfo
+func (a *DefaultAPIService) V1AlliancesGetExecute(r ApiV1AlliancesGetRequest) ([]V1AllianceInfo, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  *V1CityInfo
+		localVarReturnValue  []V1AllianceInfo
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidInfoGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/info"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/alliances"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -638,63 +612,2836 @@ func (a *DefaultAPIService) V1CitiesCityidInfoGetExecute(r ApiV1CitiesCityidInfo
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1AlliancesPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	v1AllianceInfo *V1AllianceInfo
+}
+
+func (r ApiV1AlliancesPostRequest) V1AllianceInfo(v1AllianceInfo V1AllianceInfo) ApiV1AlliancesPostRequest {
+	r.v1AllianceInfo = &v1AllianceInfo
+	return r
+}
+
+func (r ApiV1AlliancesPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1AlliancesPostExecute(r)
+}
+
+/*
+V1AlliancesPost Create an alliance led by the player.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1AlliancesPostRequest
+*/
+func (a *DefaultAPIService) V1AlliancesPost(ctx context.Context) ApiV1AlliancesPostRequest {
+	return ApiV1AlliancesPostRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1AlliancesPostExecute(r ApiV1AlliancesPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1AlliancesPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/alliances"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1AllianceInfo == nil {
+		return nil, reportError("v1AllianceInfo is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1AllianceInfo
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidBuildingqitemsGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Lastid(lastid string) ApiV1CitiesCityidBuildingqitemsGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Pagesize(pagesize int32) ApiV1CitiesCityidBuildingqitemsGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsGetRequest) Execute() ([]V1BuildingQueueItem, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidBuildingqitemsGetExecute(r)
+}
+
+/*
+V1CitiesCityidBuildingqitemsGet List building queue items.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidBuildingqitemsGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsGet(ctx context.Context, cityid string) ApiV1CitiesCityidBuildingqitemsGetRequest {
+	return ApiV1CitiesCityidBuildingqitemsGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+//  @return []V1BuildingQueueItem
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsGetExecute(r ApiV1CitiesCityidBuildingqitemsGetRequest) ([]V1BuildingQueueItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1BuildingQueueItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidBuildingqitemsItemidGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	itemid string
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsItemidGetRequest) Execute() (*V1BuildingQueueItem, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidBuildingqitemsItemidGetExecute(r)
+}
+
+/*
+V1CitiesCityidBuildingqitemsItemidGet Get a building queue item.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @param itemid
+ @return ApiV1CitiesCityidBuildingqitemsItemidGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGet(ctx context.Context, cityid string, itemid string) ApiV1CitiesCityidBuildingqitemsItemidGetRequest {
+	return ApiV1CitiesCityidBuildingqitemsItemidGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+		itemid: itemid,
+	}
+}
+
+// Execute executes the request
+//  @return V1BuildingQueueItem
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsItemidGetExecute(r ApiV1CitiesCityidBuildingqitemsItemidGetRequest) (*V1BuildingQueueItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1BuildingQueueItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsItemidGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems/{itemid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"itemid"+"}", url.PathEscape(parameterValueToString(r.itemid, "itemid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidBuildingqitemsPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	v1BuildingQueueItem *V1BuildingQueueItem
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsPostRequest) V1BuildingQueueItem(v1BuildingQueueItem V1BuildingQueueItem) ApiV1CitiesCityidBuildingqitemsPostRequest {
+	r.v1BuildingQueueItem = &v1BuildingQueueItem
+	return r
+}
+
+func (r ApiV1CitiesCityidBuildingqitemsPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesCityidBuildingqitemsPostExecute(r)
+}
+
+/*
+V1CitiesCityidBuildingqitemsPost Add a queue item.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidBuildingqitemsPostRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPost(ctx context.Context, cityid string) ApiV1CitiesCityidBuildingqitemsPostRequest {
+	return ApiV1CitiesCityidBuildingqitemsPostRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesCityidBuildingqitemsPostExecute(r ApiV1CitiesCityidBuildingqitemsPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidBuildingqitemsPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/buildingqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1BuildingQueueItem == nil {
+		return nil, reportError("v1BuildingQueueItem is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1BuildingQueueItem
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidDeleteRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+}
+
+func (r ApiV1CitiesCityidDeleteRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesCityidDeleteExecute(r)
+}
+
+/*
+V1CitiesCityidDelete Delete a city.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidDeleteRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidDelete(ctx context.Context, cityid string) ApiV1CitiesCityidDeleteRequest {
+	return ApiV1CitiesCityidDeleteRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesCityidDeleteExecute(r ApiV1CitiesCityidDeleteRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidDelete")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+}
+
+func (r ApiV1CitiesCityidGetRequest) Execute() (*V1City, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidGetExecute(r)
+}
+
+/*
+V1CitiesCityidGet Get a city complete state.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidGet(ctx context.Context, cityid string) ApiV1CitiesCityidGetRequest {
+	return ApiV1CitiesCityidGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+//  @return V1City
+func (a *DefaultAPIService) V1CitiesCityidGetExecute(r ApiV1CitiesCityidGetRequest) (*V1City, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1City
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidInfoGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+}
+
+func (r ApiV1CitiesCityidInfoGetRequest) Execute() (*V1CityInfo, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidInfoGetExecute(r)
+}
+
+/*
+V1CitiesCityidInfoGet Get a city info.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidInfoGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidInfoGet(ctx context.Context, cityid string) ApiV1CitiesCityidInfoGetRequest {
+	return ApiV1CitiesCityidInfoGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+//  @return V1CityInfo
+func (a *DefaultAPIService) V1CitiesCityidInfoGetExecute(r ApiV1CitiesCityidInfoGetRequest) (*V1CityInfo, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1CityInfo
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidInfoGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/info"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidReinforcementsRecallPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+}
+
+func (r ApiV1CitiesCityidReinforcementsRecallPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesCityidReinforcementsRecallPostExecute(r)
+}
+
+/*
+V1CitiesCityidReinforcementsRecallPost Send the units of the player stationed in an allied city back to the closest city of the player.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidReinforcementsRecallPostRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidReinforcementsRecallPost(ctx context.Context, cityid string) ApiV1CitiesCityidReinforcementsRecallPostRequest {
+	return ApiV1CitiesCityidReinforcementsRecallPostRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesCityidReinforcementsRecallPostExecute(r ApiV1CitiesCityidReinforcementsRecallPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidReinforcementsRecallPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/reinforcements/recall"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidResearchqitemsGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1CitiesCityidResearchqitemsGetRequest) Lastid(lastid string) ApiV1CitiesCityidResearchqitemsGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1CitiesCityidResearchqitemsGetRequest) Pagesize(pagesize int32) ApiV1CitiesCityidResearchqitemsGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1CitiesCityidResearchqitemsGetRequest) Execute() ([]V1ResearchQueueItem, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidResearchqitemsGetExecute(r)
+}
+
+/*
+V1CitiesCityidResearchqitemsGet List research queue items.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidResearchqitemsGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidResearchqitemsGet(ctx context.Context, cityid string) ApiV1CitiesCityidResearchqitemsGetRequest {
+	return ApiV1CitiesCityidResearchqitemsGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+//  @return []V1ResearchQueueItem
+func (a *DefaultAPIService) V1CitiesCityidResearchqitemsGetExecute(r ApiV1CitiesCityidResearchqitemsGetRequest) ([]V1ResearchQueueItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1ResearchQueueItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidResearchqitemsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/researchqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidResearchqitemsPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	v1ResearchQueueItem *V1ResearchQueueItem
+}
+
+func (r ApiV1CitiesCityidResearchqitemsPostRequest) V1ResearchQueueItem(v1ResearchQueueItem V1ResearchQueueItem) ApiV1CitiesCityidResearchqitemsPostRequest {
+	r.v1ResearchQueueItem = &v1ResearchQueueItem
+	return r
+}
+
+func (r ApiV1CitiesCityidResearchqitemsPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesCityidResearchqitemsPostExecute(r)
+}
+
+/*
+V1CitiesCityidResearchqitemsPost Research something for the player, paid by the city.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidResearchqitemsPostRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidResearchqitemsPost(ctx context.Context, cityid string) ApiV1CitiesCityidResearchqitemsPostRequest {
+	return ApiV1CitiesCityidResearchqitemsPostRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesCityidResearchqitemsPostExecute(r ApiV1CitiesCityidResearchqitemsPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidResearchqitemsPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/researchqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1ResearchQueueItem == nil {
+		return nil, reportError("v1ResearchQueueItem is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1ResearchQueueItem
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidUnitqitemsGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1CitiesCityidUnitqitemsGetRequest) Lastid(lastid string) ApiV1CitiesCityidUnitqitemsGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1CitiesCityidUnitqitemsGetRequest) Pagesize(pagesize int32) ApiV1CitiesCityidUnitqitemsGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1CitiesCityidUnitqitemsGetRequest) Execute() ([]V1UnitQueueItem, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidUnitqitemsGetExecute(r)
+}
+
+/*
+V1CitiesCityidUnitqitemsGet List unit queue items.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidUnitqitemsGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsGet(ctx context.Context, cityid string) ApiV1CitiesCityidUnitqitemsGetRequest {
+	return ApiV1CitiesCityidUnitqitemsGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+//  @return []V1UnitQueueItem
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsGetExecute(r ApiV1CitiesCityidUnitqitemsGetRequest) ([]V1UnitQueueItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1UnitQueueItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidUnitqitemsItemidGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	itemid string
+}
+
+func (r ApiV1CitiesCityidUnitqitemsItemidGetRequest) Execute() (*V1UnitQueueItem, *http.Response, error) {
+	return r.ApiService.V1CitiesCityidUnitqitemsItemidGetExecute(r)
+}
+
+/*
+V1CitiesCityidUnitqitemsItemidGet Get a unit queue item.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @param itemid
+ @return ApiV1CitiesCityidUnitqitemsItemidGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGet(ctx context.Context, cityid string, itemid string) ApiV1CitiesCityidUnitqitemsItemidGetRequest {
+	return ApiV1CitiesCityidUnitqitemsItemidGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+		itemid: itemid,
+	}
+}
+
+// Execute executes the request
+//  @return V1UnitQueueItem
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGetExecute(r ApiV1CitiesCityidUnitqitemsItemidGetRequest) (*V1UnitQueueItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1UnitQueueItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsItemidGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems/{itemid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"itemid"+"}", url.PathEscape(parameterValueToString(r.itemid, "itemid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesCityidUnitqitemsPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	cityid string
+	v1UnitQueueItem *V1UnitQueueItem
+}
+
+func (r ApiV1CitiesCityidUnitqitemsPostRequest) V1UnitQueueItem(v1UnitQueueItem V1UnitQueueItem) ApiV1CitiesCityidUnitqitemsPostRequest {
+	r.v1UnitQueueItem = &v1UnitQueueItem
+	return r
+}
+
+func (r ApiV1CitiesCityidUnitqitemsPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesCityidUnitqitemsPostExecute(r)
+}
+
+/*
+V1CitiesCityidUnitqitemsPost Add a queue item.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param cityid
+ @return ApiV1CitiesCityidUnitqitemsPostRequest
+*/
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPost(ctx context.Context, cityid string) ApiV1CitiesCityidUnitqitemsPostRequest {
+	return ApiV1CitiesCityidUnitqitemsPostRequest{
+		ApiService: a,
+		ctx: ctx,
+		cityid: cityid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPostExecute(r ApiV1CitiesCityidUnitqitemsPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems"
+	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1UnitQueueItem == nil {
+		return nil, reportError("v1UnitQueueItem is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1UnitQueueItem
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	lastid *string
+	pagesize *int32
+	playerid *string
+	locationbounds *[]int32
+	near *[]int32
+	radius *int32
+}
+
+func (r ApiV1CitiesGetRequest) Lastid(lastid string) ApiV1CitiesGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Pagesize(pagesize int32) ApiV1CitiesGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Playerid(playerid string) ApiV1CitiesGetRequest {
+	r.playerid = &playerid
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Locationbounds(locationbounds []int32) ApiV1CitiesGetRequest {
+	r.locationbounds = &locationbounds
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Near(near []int32) ApiV1CitiesGetRequest {
+	r.near = &near
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Radius(radius int32) ApiV1CitiesGetRequest {
+	r.radius = &radius
+	return r
+}
+
+func (r ApiV1CitiesGetRequest) Execute() ([]V1CityInfo, *http.Response, error) {
+	return r.ApiService.V1CitiesGetExecute(r)
+}
+
+/*
+V1CitiesGet List city infos with the given filters.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1CitiesGetRequest
+*/
+func (a *DefaultAPIService) V1CitiesGet(ctx context.Context) ApiV1CitiesGetRequest {
+	return ApiV1CitiesGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return []V1CityInfo
+func (a *DefaultAPIService) V1CitiesGetExecute(r ApiV1CitiesGetRequest) ([]V1CityInfo, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1CityInfo
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	if r.playerid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "playerid", r.playerid, "")
+	}
+	if r.locationbounds != nil {
+		t := *r.locationbounds
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				parameterAddToHeaderOrQuery(localVarQueryParams, "locationbounds", s.Index(i).Interface(), "multi")
+			}
+		} else {
+			parameterAddToHeaderOrQuery(localVarQueryParams, "locationbounds", t, "multi")
+		}
+	}
+	if r.near != nil {
+		t := *r.near
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				parameterAddToHeaderOrQuery(localVarQueryParams, "near", s.Index(i).Interface(), "multi")
+			}
+		} else {
+			parameterAddToHeaderOrQuery(localVarQueryParams, "near", t, "multi")
+		}
+	}
+	if r.radius != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "radius", r.radius, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1CitiesPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	v1CityInfo *V1CityInfo
+}
+
+func (r ApiV1CitiesPostRequest) V1CityInfo(v1CityInfo V1CityInfo) ApiV1CitiesPostRequest {
+	r.v1CityInfo = &v1CityInfo
+	return r
+}
+
+func (r ApiV1CitiesPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1CitiesPostExecute(r)
+}
+
+/*
+V1CitiesPost Create a city.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1CitiesPostRequest
+*/
+func (a *DefaultAPIService) V1CitiesPost(ctx context.Context) ApiV1CitiesPostRequest {
+	return ApiV1CitiesPostRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1CitiesPostExecute(r ApiV1CitiesPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/cities"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1CityInfo == nil {
+		return nil, reportError("v1CityInfo is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1CityInfo
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1ConfigGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+}
+
+func (r ApiV1ConfigGetRequest) Execute() (*V1GameConfig, *http.Response, error) {
+	return r.ApiService.V1ConfigGetExecute(r)
+}
+
+/*
+V1ConfigGet Get the game configuration of the world.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1ConfigGetRequest
+*/
+func (a *DefaultAPIService) V1ConfigGet(ctx context.Context) ApiV1ConfigGetRequest {
+	return ApiV1ConfigGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return V1GameConfig
+func (a *DefaultAPIService) V1ConfigGetExecute(r ApiV1ConfigGetRequest) (*V1GameConfig, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1GameConfig
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1ConfigGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/config"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1ConfigRequirementsGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+}
+
+func (r ApiV1ConfigRequirementsGetRequest) Execute() (*V1Requirements, *http.Response, error) {
+	return r.ApiService.V1ConfigRequirementsGetExecute(r)
+}
+
+/*
+V1ConfigRequirementsGet Get what every unit, building and research requires, to render the tech graph.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1ConfigRequirementsGetRequest
+*/
+func (a *DefaultAPIService) V1ConfigRequirementsGet(ctx context.Context) ApiV1ConfigRequirementsGetRequest {
+	return ApiV1ConfigRequirementsGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return V1Requirements
+func (a *DefaultAPIService) V1ConfigRequirementsGetExecute(r ApiV1ConfigRequirementsGetRequest) (*V1Requirements, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1Requirements
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1ConfigRequirementsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/config/requirements"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1LeaderboardsKindGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	kind string
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1LeaderboardsKindGetRequest) Lastid(lastid string) ApiV1LeaderboardsKindGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1LeaderboardsKindGetRequest) Pagesize(pagesize int32) ApiV1LeaderboardsKindGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1LeaderboardsKindGetRequest) Execute() ([]V1LeaderboardEntry, *http.Response, error) {
+	return r.ApiService.V1LeaderboardsKindGetExecute(r)
+}
+
+/*
+V1LeaderboardsKindGet List the ranking of players (overall, attack, defence) or alliances (alliance).
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param kind
+ @return ApiV1LeaderboardsKindGetRequest
+*/
+func (a *DefaultAPIService) V1LeaderboardsKindGet(ctx context.Context, kind string) ApiV1LeaderboardsKindGetRequest {
+	return ApiV1LeaderboardsKindGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		kind: kind,
+	}
+}
+
+// Execute executes the request
+//  @return []V1LeaderboardEntry
+func (a *DefaultAPIService) V1LeaderboardsKindGetExecute(r ApiV1LeaderboardsKindGetRequest) ([]V1LeaderboardEntry, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1LeaderboardEntry
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1LeaderboardsKindGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/leaderboards/{kind}"
+	localVarPath = strings.Replace(localVarPath, "{"+"kind"+"}", url.PathEscape(parameterValueToString(r.kind, "kind")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1MailInboxGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1MailInboxGetRequest) Lastid(lastid string) ApiV1MailInboxGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1MailInboxGetRequest) Pagesize(pagesize int32) ApiV1MailInboxGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1MailInboxGetRequest) Execute() ([]V1Mail, *http.Response, error) {
+	return r.ApiService.V1MailInboxGetExecute(r)
+}
+
+/*
+V1MailInboxGet List the mail received by the player, including system generated mail.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1MailInboxGetRequest
+*/
+func (a *DefaultAPIService) V1MailInboxGet(ctx context.Context) ApiV1MailInboxGetRequest {
+	return ApiV1MailInboxGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return []V1Mail
+func (a *DefaultAPIService) V1MailInboxGetExecute(r ApiV1MailInboxGetRequest) ([]V1Mail, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1Mail
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailInboxGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail/inbox"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1MailMailidDeleteRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	mailid string
+}
+
+func (r ApiV1MailMailidDeleteRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MailMailidDeleteExecute(r)
+}
+
+/*
+V1MailMailidDelete Delete a mail from the player inbox and outbox.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param mailid
+ @return ApiV1MailMailidDeleteRequest
+*/
+func (a *DefaultAPIService) V1MailMailidDelete(ctx context.Context, mailid string) ApiV1MailMailidDeleteRequest {
+	return ApiV1MailMailidDeleteRequest{
+		ApiService: a,
+		ctx: ctx,
+		mailid: mailid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1MailMailidDeleteExecute(r ApiV1MailMailidDeleteRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailMailidDelete")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail/{mailid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"mailid"+"}", url.PathEscape(parameterValueToString(r.mailid, "mailid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1MailMailidGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	mailid string
+}
+
+func (r ApiV1MailMailidGetRequest) Execute() (*V1Mail, *http.Response, error) {
+	return r.ApiService.V1MailMailidGetExecute(r)
+}
+
+/*
+V1MailMailidGet Get a mail sent or received by the player.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param mailid
+ @return ApiV1MailMailidGetRequest
+*/
+func (a *DefaultAPIService) V1MailMailidGet(ctx context.Context, mailid string) ApiV1MailMailidGetRequest {
+	return ApiV1MailMailidGetRequest{
+		ApiService: a,
+		ctx: ctx,
+		mailid: mailid,
+	}
+}
+
+// Execute executes the request
+//  @return V1Mail
+func (a *DefaultAPIService) V1MailMailidGetExecute(r ApiV1MailMailidGetRequest) (*V1Mail, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1Mail
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailMailidGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail/{mailid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"mailid"+"}", url.PathEscape(parameterValueToString(r.mailid, "mailid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1MailMailidReadPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	mailid string
+}
+
+func (r ApiV1MailMailidReadPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MailMailidReadPostExecute(r)
+}
+
+/*
+V1MailMailidReadPost Mark a received mail as read.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param mailid
+ @return ApiV1MailMailidReadPostRequest
+*/
+func (a *DefaultAPIService) V1MailMailidReadPost(ctx context.Context, mailid string) ApiV1MailMailidReadPostRequest {
+	return ApiV1MailMailidReadPostRequest{
+		ApiService: a,
+		ctx: ctx,
+		mailid: mailid,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1MailMailidReadPostExecute(r ApiV1MailMailidReadPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailMailidReadPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail/{mailid}/read"
+	localVarPath = strings.Replace(localVarPath, "{"+"mailid"+"}", url.PathEscape(parameterValueToString(r.mailid, "mailid")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1MailOutboxGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	lastid *string
+	pagesize *int32
+}
+
+func (r ApiV1MailOutboxGetRequest) Lastid(lastid string) ApiV1MailOutboxGetRequest {
+	r.lastid = &lastid
+	return r
+}
+
+func (r ApiV1MailOutboxGetRequest) Pagesize(pagesize int32) ApiV1MailOutboxGetRequest {
+	r.pagesize = &pagesize
+	return r
+}
+
+func (r ApiV1MailOutboxGetRequest) Execute() ([]V1Mail, *http.Response, error) {
+	return r.ApiService.V1MailOutboxGetExecute(r)
+}
+
+/*
+V1MailOutboxGet List the mail sent by the player.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1MailOutboxGetRequest
+*/
+func (a *DefaultAPIService) V1MailOutboxGet(ctx context.Context) ApiV1MailOutboxGetRequest {
+	return ApiV1MailOutboxGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return []V1Mail
+func (a *DefaultAPIService) V1MailOutboxGetExecute(r ApiV1MailOutboxGetRequest) ([]V1Mail, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []V1Mail
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailOutboxGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail/outbox"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.lastid != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
+	}
+	if r.pagesize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1MailPostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	v1Mail *V1Mail
+}
+
+func (r ApiV1MailPostRequest) V1Mail(v1Mail V1Mail) ApiV1MailPostRequest {
+	r.v1Mail = &v1Mail
+	return r
+}
+
+func (r ApiV1MailPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MailPostExecute(r)
+}
+
+/*
+V1MailPost Send a mail to a player or to every member of an alliance.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1MailPostRequest
+*/
+func (a *DefaultAPIService) V1MailPost(ctx context.Context) ApiV1MailPostRequest {
+	return ApiV1MailPostRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1MailPostExecute(r ApiV1MailPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MailPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/mail"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1Mail == nil {
+		return nil, reportError("v1Mail is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1Mail
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type ApiV1MapGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	x1 *int32
+	y1 *int32
+	x2 *int32
+	y2 *int32
+	zoomedout *bool
+}
+
+func (r ApiV1MapGetRequest) X1(x1 int32) ApiV1MapGetRequest {
+	r.x1 = &x1
+	return r
+}
+
+func (r ApiV1MapGetRequest) Y1(y1 int32) ApiV1MapGetRequest {
+	r.y1 = &y1
+	return r
+}
+
+func (r ApiV1MapGetRequest) X2(x2 int32) ApiV1MapGetRequest {
+	r.x2 = &x2
+	return r
+}
+
+func (r ApiV1MapGetRequest) Y2(y2 int32) ApiV1MapGetRequest {
+	r.y2 = &y2
+	return r
+}
+
+func (r ApiV1MapGetRequest) Zoomedout(zoomedout bool) ApiV1MapGetRequest {
+	r.zoomedout = &zoomedout
+	return r
+}
+
+func (r ApiV1MapGetRequest) Execute() (*V1Map, *http.Response, error) {
+	return r.ApiService.V1MapGetExecute(r)
+}
+
+/*
+V1MapGet Get the map within the bounds, tile by tile or zoomed out into regions.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1MapGetRequest
+*/
+func (a *DefaultAPIService) V1MapGet(ctx context.Context) ApiV1MapGetRequest {
+	return ApiV1MapGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return V1Map
+func (a *DefaultAPIService) V1MapGetExecute(r ApiV1MapGetRequest) (*V1Map, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1Map
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MapGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/map"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.x1 == nil {
+		return localVarReturnValue, nil, reportError("x1 is required and must be specified")
+	}
+	if r.y1 == nil {
+		return localVarReturnValue, nil, reportError("y1 is required and must be specified")
+	}
+	if r.x2 == nil {
+		return localVarReturnValue, nil, reportError("x2 is required and must be specified")
+	}
+	if r.y2 == nil {
+		return localVarReturnValue, nil, reportError("y2 is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "x1", r.x1, "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "y1", r.y1, "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "x2", r.x2, "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "y2", r.y2, "")
+	if r.zoomedout != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "zoomedout", r.zoomedout, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiV1MarketExchangePostRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+	v1ResourceExchange *V1ResourceExchange
+}
+
+func (r ApiV1MarketExchangePostRequest) V1ResourceExchange(v1ResourceExchange V1ResourceExchange) ApiV1MarketExchangePostRequest {
+	r.v1ResourceExchange = &v1ResourceExchange
+	return r
+}
+
+func (r ApiV1MarketExchangePostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MarketExchangePostExecute(r)
+}
+
+/*
+V1MarketExchangePost Exchange resources of a city for others at the rates of the game config, once the city meets the requirements of the exchange.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1MarketExchangePostRequest
+*/
+func (a *DefaultAPIService) V1MarketExchangePost(ctx context.Context) ApiV1MarketExchangePostRequest {
+	return ApiV1MarketExchangePostRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) V1MarketExchangePostExecute(r ApiV1MarketExchangePostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketExchangePost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/market/exchange"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.v1ResourceExchange == nil {
+		return nil, reportError("v1ResourceExchange is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.v1ResourceExchange
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidUnitqitemsGetRequest struct {
+type ApiV1MarketOffersGetRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
 	lastid *string
 	pagesize *int32
 }
 
-func (r ApiV1CitiesCityidUnitqitemsGetRequest) Lastid(lastid string) ApiV1CitiesCityidUnitqitemsGetRequest {
+func (r ApiV1MarketOffersGetRequest) Lastid(lastid string) ApiV1MarketOffersGetRequest {
 	r.lastid = &lastid
 	return r
 }
 
-func (r ApiV1CitiesCityidUnitqitemsGetRequest) Pagesize(pagesize int32) ApiV1CitiesCityidUnitqitemsGetRequest {
+func (r ApiV1MarketOffersGetRequest) Pagesize(pagesize int32) ApiV1MarketOffersGetRequest {
 	r.pagesize = &pagesize
 	return r
 }
 
-func (r ApiV1CitiesCityidUnitqitemsGetRequest) Execute() ([]V1UnitQueueItem, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidUnitqitemsGetExecute(r)
+func (r ApiV1MarketOffersGetRequest) Execute() ([]V1TradeOffer, *http.Response, error) {
+	return r.ApiService.V1MarketOffersGetExecute(r)
 }
 
 /*
-V1CitiesCityidUnitqitemsGet List unit queue items.
+V1MarketOffersGet List the open trade offers of every player.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidUnitqitemsGetRequest
+ @return ApiV1MarketOffersGetRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsGet(ctx context.Context, cityid string) ApiV1CitiesCityidUnitqitemsGetRequest {
-	return ApiV1CitiesCityidUnitqitemsGetRequest{
+func (a *DefaultAPIService) V1MarketOffersGet(ctx context.Context) ApiV1MarketOffersGetRequest {
+	return ApiV1MarketOffersGetRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
 	}
 }
 
 // Execute executes the request
-//  @return []V1UnitQueueItem
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsGetExecute(r ApiV1CitiesCityidUnitqitemsGetRequest) ([]V1UnitQueueItem, *http.Response, error) {
+//  @return []V1TradeOffer
+func (a *DefaultAPIService) V1MarketOffersGetExecute(r ApiV1MarketOffersGetRequest) ([]V1TradeOffer, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  []V1UnitQueueItem
+		localVarReturnValue  []V1TradeOffer
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketOffersGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/market/offers"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -760,59 +3507,62 @@ func (a *DefaultAPIService) V1CitiesCityidUnitqitemsGetExecute(r ApiV1CitiesCity
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidUnitqitemsItemidGetRequest struct {
+type ApiV1MarketOffersOfferidAcceptPostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
-	itemid string
+	offerid string
+	v1TradeOfferAcceptance *V1TradeOfferAcceptance
 }
 
-func (r ApiV1CitiesCityidUnitqitemsItemidGetRequest) Execute() (*V1UnitQueueItem, *http.Response, error) {
-	return r.ApiService.V1CitiesCityidUnitqitemsItemidGetExecute(r)
+func (r ApiV1MarketOffersOfferidAcceptPostRequest) V1TradeOfferAcceptance(v1TradeOfferAcceptance V1TradeOfferAcceptance) ApiV1MarketOffersOfferidAcceptPostRequest {
+	r.v1TradeOfferAcceptance = &v1TradeOfferAcceptance
+	return r
+}
+
+func (r ApiV1MarketOffersOfferidAcceptPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MarketOffersOfferidAcceptPostExecute(r)
 }
 
 /*
-V1CitiesCityidUnitqitemsItemidGet Get a unit queue item.
+V1MarketOffersOfferidAcceptPost Accept the trade offer of another player, both sides are delivered by transports.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @param itemid
- @return ApiV1CitiesCityidUnitqitemsItemidGetRequest
+ @param offerid
+ @return ApiV1MarketOffersOfferidAcceptPostRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGet(ctx context.Context, cityid string, itemid string) ApiV1CitiesCityidUnitqitemsItemidGetRequest {
-	return ApiV1CitiesCityidUnitqitemsItemidGetRequest{
+func (a *DefaultAPIService) V1MarketOffersOfferidAcceptPost(ctx context.Context, offerid string) ApiV1MarketOffersOfferidAcceptPostRequest {
+	return ApiV1MarketOffersOfferidAcceptPostRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
-		itemid: itemid,
+		offerid: offerid,
 	}
 }
 
 // Execute executes the request
-//  @return V1UnitQueueItem
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGetExecute(r ApiV1CitiesCityidUnitqitemsItemidGetRequest) (*V1UnitQueueItem, *http.Response, error) {
+func (a *DefaultAPIService) V1MarketOffersOfferidAcceptPostExecute(r ApiV1MarketOffersOfferidAcceptPostRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  *V1UnitQueueItem
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsItemidGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketOffersOfferidAcceptPost")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems/{itemid}"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
-	localVarPath = strings.Replace(localVarPath, "{"+"itemid"+"}", url.PathEscape(parameterValueToString(r.itemid, "itemid")), -1)
+	localVarPath := localBasePath + "/v1/market/offers/{offerid}/accept"
+	localVarPath = strings.Replace(localVarPath, "{"+"offerid"+"}", url.PathEscape(parameterValueToString(r.offerid, "offerid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.v1TradeOfferAcceptance == nil {
+		return nil, reportError("v1TradeOfferAcceptance is required and must be specified")
+	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -821,28 +3571,30 @@ func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGetExecute(r ApiV1Citi
 	}
 
 	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{"application/json"}
+	localVarHTTPHeaderAccepts := []string{}
 
 	// set Accept header
 	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.v1TradeOfferAcceptance
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -850,77 +3602,59 @@ func (a *DefaultAPIService) V1CitiesCityidUnitqitemsItemidGetExecute(r ApiV1Citi
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesCityidUnitqitemsPostRequest struct {
+type ApiV1MarketOffersOfferidCancelPostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	cityid string
-	v1UnitQueueItem *V1UnitQueueItem
-}
-
-func (r ApiV1CitiesCityidUnitqitemsPostRequest) V1UnitQueueItem(v1UnitQueueItem V1UnitQueueItem) ApiV1CitiesCityidUnitqitemsPostRequest {
-	r.v1UnitQueueItem = &v1UnitQueueItem
-	return r
+	offerid string
 }
 
-func (r ApiV1CitiesCityidUnitqitemsPostRequest) Execute() (*http.Response, error) {
-	return r.ApiService.V1CitiesCityidUnitqitemsPostExecute(r)
+func (r ApiV1MarketOffersOfferidCancelPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MarketOffersOfferidCancelPostExecute(r)
 }
 
 /*
-V1CitiesCityidUnitqitemsPost Add a queue item.
+V1MarketOffersOfferidCancelPost Cancel a trade offer of the player, the offered resources go back to the city.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @param cityid
- @return ApiV1CitiesCityidUnitqitemsPostRequest
+ @param offerid
+ @return ApiV1MarketOffersOfferidCancelPostRequest
 */
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPost(ctx context.Context, cityid string) ApiV1CitiesCityidUnitqitemsPostRequest {
-	return ApiV1CitiesCityidUnitqitemsPostRequest{
+func (a *DefaultAPIService) V1MarketOffersOfferidCancelPost(ctx context.Context, offerid string) ApiV1MarketOffersOfferidCancelPostRequest {
+	return ApiV1MarketOffersOfferidCancelPostRequest{
 		ApiService: a,
 		ctx: ctx,
-		cityid: cityid,
+		offerid: offerid,
 	}
 }
 
 // Execute executes the request
-func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPostExecute(r ApiV1CitiesCityidUnitqitemsPostRequest) (*http.Response, error) {
+func (a *DefaultAPIService) V1MarketOffersOfferidCancelPostExecute(r ApiV1MarketOffersOfferidCancelPostRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesCityidUnitqitemsPost")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketOffersOfferidCancelPost")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities/{cityid}/unitqitems"
-	localVarPath = strings.Replace(localVarPath, "{"+"cityid"+"}", url.PathEscape(parameterValueToString(r.cityid, "cityid")), -1)
+	localVarPath := localBasePath + "/v1/market/offers/{offerid}/cancel"
+	localVarPath = strings.Replace(localVarPath, "{"+"offerid"+"}", url.PathEscape(parameterValueToString(r.offerid, "offerid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.v1UnitQueueItem == nil {
-		return nil, reportError("v1UnitQueueItem is required and must be specified")
-	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -936,8 +3670,6 @@ func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPostExecute(r ApiV1CitiesCit
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.v1UnitQueueItem
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -966,93 +3698,53 @@ func (a *DefaultAPIService) V1CitiesCityidUnitqitemsPostExecute(r ApiV1CitiesCit
 	return localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesGetRequest struct {
+type ApiV1MarketOffersOfferidGetRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	lastid *string
-	pagesize *int32
-	playerid *string
-	locationbounds *[]int32
-}
-
-func (r ApiV1CitiesGetRequest) Lastid(lastid string) ApiV1CitiesGetRequest {
-	r.lastid = &lastid
-	return r
-}
-
-func (r ApiV1CitiesGetRequest) Pagesize(pagesize int32) ApiV1CitiesGetRequest {
-	r.pagesize = &pagesize
-	return r
-}
-
-func (r ApiV1CitiesGetRequest) Playerid(playerid string) ApiV1CitiesGetRequest {
-	r.playerid = &playerid
-	return r
-}
-
-func (r ApiV1CitiesGetRequest) Locationbounds(locationbounds []int32) ApiV1CitiesGetRequest {
-	r.locationbounds = &locationbounds
-	return r
+	offerid string
 }
 
-func (r ApiV1CitiesGetRequest) Execute() ([]V1CityInfo, *http.Response, error) {
-	return r.ApiService.V1CitiesGetExecute(r)
+func (r ApiV1MarketOffersOfferidGetRequest) Execute() (*V1TradeOffer, *http.Response, error) {
+	return r.ApiService.V1MarketOffersOfferidGetExecute(r)
 }
 
 /*
-V1CitiesGet List city infos with the given filters.
+V1MarketOffersOfferidGet Get an open trade offer.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @return ApiV1CitiesGetRequest
+ @param offerid
+ @return ApiV1MarketOffersOfferidGetRequest
 */
-func (a *DefaultAPIService) V1CitiesGet(ctx context.Context) ApiV1CitiesGetRequest {
-	return ApiV1CitiesGetRequest{
+func (a *DefaultAPIService) V1MarketOffersOfferidGet(ctx context.Context, offerid string) ApiV1MarketOffersOfferidGetRequest {
+	return ApiV1MarketOffersOfferidGetRequest{
 		ApiService: a,
 		ctx: ctx,
+		offerid: offerid,
 	}
 }
 
 // Execute executes the request
-//  @return []V1CityInfo
-func (a *DefaultAPIService) V1CitiesGetExecute(r ApiV1CitiesGetRequest) ([]V1CityInfo, *http.Response, error) {
+//  @return V1TradeOffer
+func (a *DefaultAPIService) V1MarketOffersOfferidGetExecute(r ApiV1MarketOffersOfferidGetRequest) (*V1TradeOffer, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  []V1CityInfo
+		localVarReturnValue  *V1TradeOffer
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketOffersOfferidGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities"
+	localVarPath := localBasePath + "/v1/market/offers/{offerid}"
+	localVarPath = strings.Replace(localVarPath, "{"+"offerid"+"}", url.PathEscape(parameterValueToString(r.offerid, "offerid")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.lastid != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "lastid", r.lastid, "")
-	}
-	if r.pagesize != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "pagesize", r.pagesize, "")
-	}
-	if r.playerid != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "playerid", r.playerid, "")
-	}
-	if r.locationbounds != nil {
-		t := *r.locationbounds
-		if reflect.TypeOf(t).Kind() == reflect.Slice {
-			s := reflect.ValueOf(t)
-			for i := 0; i < s.Len(); i++ {
-				parameterAddToHeaderOrQuery(localVarQueryParams, "locationbounds", s.Index(i).Interface(), "multi")
-			}
-		} else {
-			parameterAddToHeaderOrQuery(localVarQueryParams, "locationbounds", t, "multi")
-		}
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1107,54 +3799,54 @@ func (a *DefaultAPIService) V1CitiesGetExecute(r ApiV1CitiesGetRequest) ([]V1Cit
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiV1CitiesPostRequest struct {
+type ApiV1MarketOffersPostRequest struct {
 	ctx context.Context
 	ApiService *DefaultAPIService
-	v1CityInfo *V1CityInfo
+	v1TradeOffer *V1TradeOffer
 }
 
-func (r ApiV1CitiesPostRequest) V1CityInfo(v1CityInfo V1CityInfo) ApiV1CitiesPostRequest {
-	r.v1CityInfo = &v1CityInfo
+func (r ApiV1MarketOffersPostRequest) V1TradeOffer(v1TradeOffer V1TradeOffer) ApiV1MarketOffersPostRequest {
+	r.v1TradeOffer = &v1TradeOffer
 	return r
 }
 
-func (r ApiV1CitiesPostRequest) Execute() (*http.Response, error) {
-	return r.ApiService.V1CitiesPostExecute(r)
+func (r ApiV1MarketOffersPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.V1MarketOffersPostExecute(r)
 }
 
 /*
-V1CitiesPost Create a city.
+V1MarketOffersPost Offer resources of a city in exchange for others, the offered resources are held until the offer is accepted or cancelled.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- @return ApiV1CitiesPostRequest
+ @return ApiV1MarketOffersPostRequest
 */
-func (a *DefaultAPIService) V1CitiesPost(ctx context.Context) ApiV1CitiesPostRequest {
-	return ApiV1CitiesPostRequest{
+func (a *DefaultAPIService) V1MarketOffersPost(ctx context.Context) ApiV1MarketOffersPostRequest {
+	return ApiV1MarketOffersPostRequest{
 		ApiService: a,
 		ctx: ctx,
 	}
 }
 
 // Execute executes the request
-func (a *DefaultAPIService) V1CitiesPostExecute(r ApiV1CitiesPostRequest) (*http.Response, error) {
+func (a *DefaultAPIService) V1MarketOffersPostExecute(r ApiV1MarketOffersPostRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1CitiesPost")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1MarketOffersPost")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1/cities"
+	localVarPath := localBasePath + "/v1/market/offers"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.v1CityInfo == nil {
-		return nil, reportError("v1CityInfo is required and must be specified")
+	if r.v1TradeOffer == nil {
+		return nil, reportError("v1TradeOffer is required and must be specified")
 	}
 
 	// to determine the Content-Type header
@@ -1175,7 +3867,7 @@ func (a *DefaultAPIService) V1CitiesPostExecute(r ApiV1CitiesPostRequest) (*http
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.v1CityInfo
+	localVarPostBody = r.v1TradeOffer
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -1534,3 +4226,100 @@ func (a *DefaultAPIService) V1MovementsPostExecute(r ApiV1MovementsPostRequest)
 
 	return localVarHTTPResponse, nil
 }
+
+type ApiV1ResearchGetRequest struct {
+	ctx context.Context
+	ApiService *DefaultAPIService
+}
+
+func (r ApiV1ResearchGetRequest) Execute() (*V1PlayerResearch, *http.Response, error) {
+	return r.ApiService.V1ResearchGetExecute(r)
+}
+
+/*
+V1ResearchGet Get the research completed by the player.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiV1ResearchGetRequest
+*/
+func (a *DefaultAPIService) V1ResearchGet(ctx context.Context) ApiV1ResearchGetRequest {
+	return ApiV1ResearchGetRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return V1PlayerResearch
+func (a *DefaultAPIService) V1ResearchGetExecute(r ApiV1ResearchGetRequest) (*V1PlayerResearch, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *V1PlayerResearch
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.V1ResearchGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/research"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
@@ -25,6 +25,8 @@ type V1City struct {
 	Buildings map[string]int64 `json:"buildings"`
 	CityResources V1CityResources `json:"cityResources"`
 	UnitCount map[string]int64 `json:"unitCount"`
+	// Units that allies keep stationed in the city by the player they belong to, they defend it along with its own.
+	Reinforcements map[string]map[string]int64 `json:"reinforcements,omitempty"`
 }
 
 type _V1City V1City
@@ -146,6 +148,38 @@ func (o *V1City) SetUnitCount(v map[string]int64) {
 	o.UnitCount = v
 }
 
+// GetReinforcements returns the Reinforcements field value if set, zero value otherwise.
+func (o *V1City) GetReinforcements() map[string]map[string]int64 {
+	if o == nil || IsNil(o.Reinforcements) {
+		var ret map[string]map[string]int64
+		return ret
+	}
+	return o.Reinforcements
+}
+
+// GetReinforcementsOk returns a tuple with the Reinforcements field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *V1City) GetReinforcementsOk() (*map[string]map[string]int64, bool) {
+	if o == nil || IsNil(o.Reinforcements) {
+		return &map[string]map[string]int64{}, false
+	}
+	return &o.Reinforcements, true
+}
+
+// HasReinforcements returns a boolean if a field has been set.
+func (o *V1City) HasReinforcements() bool {
+	if o != nil && !IsNil(o.Reinforcements) {
+		return true
+	}
+
+	return false
+}
+
+// SetReinforcements gets a reference to the given map[string]map[string]int64 and assigns it to the Reinforcements field.
+func (o *V1City) SetReinforcements(v map[string]map[string]int64) {
+	o.Reinforcements = v
+}
+
 func (o V1City) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -160,6 +194,9 @@ func (o V1City) ToMap() (map[string]interface{}, error) {
 	toSerialize["buildings"] = o.Buildings
 	toSerialize["cityResources"] = o.CityResources
 	toSerialize["unitCount"] = o.UnitCount
+	if !IsNil(o.Reinforcements) {
+		toSerialize["reinforcements"] = o.Reinforcements
+	}
 	return toSerialize, nil
 }
 
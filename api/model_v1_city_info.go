@@ -26,6 +26,8 @@ type V1CityInfo struct {
 	PlayerID string `json:"playerID"`
 	LocationX int32 `json:"locationX"`
 	LocationY int32 `json:"locationY"`
+	// Epoch until which hostile arrivals bounce back from the city, it is over early once its owner attacks another player.
+	ProtectedUntil *int64 `json:"protectedUntil,omitempty"`
 }
 
 type _V1CityInfo V1CityInfo
@@ -172,6 +174,38 @@ func (o *V1CityInfo) SetLocationY(v int32) {
 	o.LocationY = v
 }
 
+// GetProtectedUntil returns the ProtectedUntil field value if set, zero value otherwise.
+func (o *V1CityInfo) GetProtectedUntil() int64 {
+	if o == nil || IsNil(o.ProtectedUntil) {
+		var ret int64
+		return ret
+	}
+	return *o.ProtectedUntil
+}
+
+// GetProtectedUntilOk returns a tuple with the ProtectedUntil field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *V1CityInfo) GetProtectedUntilOk() (*int64, bool) {
+	if o == nil || IsNil(o.ProtectedUntil) {
+		return nil, false
+	}
+	return o.ProtectedUntil, true
+}
+
+// HasProtectedUntil returns a boolean if a field has been set.
+func (o *V1CityInfo) HasProtectedUntil() bool {
+	if o != nil && !IsNil(o.ProtectedUntil) {
+		return true
+	}
+
+	return false
+}
+
+// SetProtectedUntil gets a reference to the given int64 and assigns it to the ProtectedUntil field.
+func (o *V1CityInfo) SetProtectedUntil(v int64) {
+	o.ProtectedUntil = &v
+}
+
 func (o V1CityInfo) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -187,6 +221,9 @@ func (o V1CityInfo) ToMap() (map[string]interface{}, error) {
 	toSerialize["playerID"] = o.PlayerID
 	toSerialize["locationX"] = o.LocationX
 	toSerialize["locationY"] = o.LocationY
+	if !IsNil(o.ProtectedUntil) {
+		toSerialize["protectedUntil"] = o.ProtectedUntil
+	}
 	return toSerialize, nil
 }
 
@@ -31,6 +31,8 @@ type V1Movement struct {
 	Speed float64 `json:"speed"`
 	UnitCount map[string]int64 `json:"unitCount"`
 	ResourceCount map[string]int64 `json:"resourceCount"`
+	// Troops by default, transports carry resources with the merchants of the city instead of units.
+	Type *string `json:"type,omitempty"`
 }
 
 type _V1Movement V1Movement
@@ -302,6 +304,38 @@ func (o *V1Movement) SetResourceCount(v map[string]int64) {
 	o.ResourceCount = v
 }
 
+// GetType returns the Type field value if set, zero value otherwise.
+func (o *V1Movement) GetType() string {
+	if o == nil || IsNil(o.Type) {
+		var ret string
+		return ret
+	}
+	return *o.Type
+}
+
+// GetTypeOk returns a tuple with the Type field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *V1Movement) GetTypeOk() (*string, bool) {
+	if o == nil || IsNil(o.Type) {
+		return nil, false
+	}
+	return o.Type, true
+}
+
+// HasType returns a boolean if a field has been set.
+func (o *V1Movement) HasType() bool {
+	if o != nil && !IsNil(o.Type) {
+		return true
+	}
+
+	return false
+}
+
+// SetType gets a reference to the given string and assigns it to the Type field.
+func (o *V1Movement) SetType(v string) {
+	o.Type = &v
+}
+
 func (o V1Movement) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -322,6 +356,9 @@ func (o V1Movement) ToMap() (map[string]interface{}, error) {
 	toSerialize["speed"] = o.Speed
 	toSerialize["unitCount"] = o.UnitCount
 	toSerialize["resourceCount"] = o.ResourceCount
+	if !IsNil(o.Type) {
+		toSerialize["type"] = o.Type
+	}
 	return toSerialize, nil
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -13,16 +14,27 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/luisferreira32/stickerio/internal"
 )
 
 type serverConfiguration struct {
 	databaseHost string
+	configPath   string
+	worldsPath   string
+	adminToken   string
 	port         string
 	resyncPeriod time.Duration
 }
 
+// A world is a whole separate game: its own config, database and event sourcer.
+type worldConfiguration struct {
+	ID           string `json:"id"`
+	ConfigPath   string `json:"config"`
+	DatabaseHost string `json:"database"`
+}
+
+const defaultWorldID = "default"
+
 func parseServerConfiguration() serverConfiguration {
 	resyncSec, err := strconv.Atoi(os.Getenv("RESYNC"))
 	if err != nil || resyncSec <= 0 {
@@ -32,75 +44,204 @@ func parseServerConfiguration() serverConfiguration {
 	if port == "" {
 		port = "8080"
 	}
+	configPath := os.Getenv("CONFIG")
+	if configPath == "" {
+		configPath = "config.json"
+	}
 
 	return serverConfiguration{
 		databaseHost: os.Getenv("DB_HOST"),
+		configPath:   configPath,
+		worldsPath:   os.Getenv("WORLDS"),
+		adminToken:   os.Getenv("ADMIN_TOKEN"),
 		port:         port,
 		resyncPeriod: time.Duration(resyncSec) * time.Second,
 	}
 }
 
+// The default world is the one from CONFIG and DB_HOST, any other world is
+// listed in the WORLDS file as a JSON array of world configurations.
+func parseWorldsConfiguration(cfg serverConfiguration) ([]worldConfiguration, error) {
+	worlds := []worldConfiguration{{ID: defaultWorldID, ConfigPath: cfg.configPath, DatabaseHost: cfg.databaseHost}}
+	if cfg.worldsPath == "" {
+		return worlds, nil
+	}
+
+	rawWorlds, err := os.ReadFile(cfg.worldsPath)
+	if err != nil {
+		return nil, err
+	}
+	extraWorlds := make([]worldConfiguration, 0)
+	err = json.Unmarshal(rawWorlds, &extraWorlds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.worldsPath, err)
+	}
+
+	seen := map[string]bool{defaultWorldID: true}
+	for _, world := range extraWorlds {
+		if world.ID == "" || seen[world.ID] {
+			return nil, fmt.Errorf("%s: empty or duplicate world id %q", cfg.worldsPath, world.ID)
+		}
+		seen[world.ID] = true
+		worlds = append(worlds, world)
+	}
+	return worlds, nil
+}
+
+// A SIGHUP re-reads the config file of every world, an invalid config is
+// reported and the world keeps running with the previous one.
+func reloadConfigsOnHangup(ctx context.Context, worlds []worldConfiguration, worldConfigs map[string]*internal.ConfigHistory) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	for {
+		select {
+		case <-hangup:
+			for _, world := range worlds {
+				_, err := worldConfigs[world.ID].Reload(ctx, world.ConfigPath)
+				if err != nil {
+					log.Printf("could not reload world %s config: %v", world.ID, err)
+					continue
+				}
+				log.Printf("reloaded world %s config from %s", world.ID, world.ConfigPath)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// The config command allows to lint a game config without starting the server:
+//
+//	stickerio-api config check <file>
+func runConfigCommand(args []string) int {
+	if len(args) != 2 || args[0] != "check" {
+		fmt.Fprintf(os.Stderr, "usage: %s config check <file>\n", os.Args[0])
+		return 2
+	}
+	err := internal.CheckConfig(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[1], err)
+		return 1
+	}
+	fmt.Printf("%s: ok\n", args[1])
+	return 0
+}
+
+// The migrate command upgrades or reverts the databases without starting the
+// server, which applies any pending migration on startup anyway. Reverting
+// drops tables, so it is done one world at a time:
+//
+//	stickerio-api migrate up
+//	stickerio-api migrate status
+//	stickerio-api migrate down <steps> <world id>
+func runMigrateCommand(ctx context.Context, args []string) int {
+	usage := func() int {
+		fmt.Fprintf(os.Stderr, "usage: %s migrate up|status|down <steps> <world id>\n", os.Args[0])
+		return 2
+	}
+	if len(args) == 0 {
+		return usage()
+	}
+	worlds, err := parseWorldsConfiguration(parseServerConfiguration())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not read worlds: %v\n", err)
+		return 1
+	}
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		for _, world := range worlds {
+			applied, err := internal.NewStickerioRepository(world.DatabaseHost).MigrateUp(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "world %s: %v\n", world.ID, err)
+				return 1
+			}
+			fmt.Printf("world %s: applied %d migrations\n", world.ID, applied)
+		}
+	case args[0] == "status" && len(args) == 1:
+		for _, world := range worlds {
+			status, err := internal.NewStickerioRepository(world.DatabaseHost).MigrationStatus(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "world %s: %v\n", world.ID, err)
+				return 1
+			}
+			for _, m := range status {
+				state := "pending"
+				if m.Applied {
+					state = "applied"
+				}
+				fmt.Printf("world %s: %04d_%s %s\n", world.ID, m.Version, m.Name, state)
+			}
+		}
+	case args[0] == "down" && len(args) == 3:
+		steps, err := strconv.Atoi(args[1])
+		if err != nil || steps <= 0 {
+			return usage()
+		}
+		for _, world := range worlds {
+			if world.ID != args[2] {
+				continue
+			}
+			reverted, err := internal.NewStickerioRepository(world.DatabaseHost).MigrateDown(ctx, steps)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "world %s: %v\n", world.ID, err)
+				return 1
+			}
+			fmt.Printf("world %s: reverted %d migrations\n", world.ID, reverted)
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "unknown world %s\n", args[2])
+		return 1
+	default:
+		return usage()
+	}
+	return 0
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(context.Background(), os.Args[2:]))
+	}
+
 	// The context should be the one controlling the lifecycle of the program
 	// ensure external SIGINT and SIGTERM are gracefully handled.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	cfg := parseServerConfiguration()
+	worlds, err := parseWorldsConfiguration(cfg)
+	if err != nil {
+		log.Fatalf("could not read worlds: %v", err)
+	}
 
-	database := internal.NewStickerioRepository(cfg.databaseHost)
-	eventSourcer := internal.NewEventSourcer(database)
-	go eventSourcer.StartEventsWorker(ctx, cfg.resyncPeriod)
-	handlers := internal.NewServerHandler(database, eventSourcer)
-
-	router := chi.NewRouter()
-
-	// middleware
-	router.Use(middleware.RequestID)
-	router.Use(middleware.RealIP)
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.Timeout(60 * time.Second))
-	router.Use(internal.WithAuthentication)
-	router.Use(internal.WithPagination)
-
-	// routes
-	router.Route(fmt.Sprintf("/%s", internal.APIVersion), func(router chi.Router) {
-		router.Get("/", handlers.GetWelcome)
-		router.Route("/cities", func(router chi.Router) {
-			router.Get("/", handlers.ListCityInfo)
-			router.Post("/", handlers.CreateCity)
-			router.With(internal.WithCityIDContext).Route(fmt.Sprintf("/{%s}", internal.CityID), func(router chi.Router) {
-				router.Get("/", handlers.GetCity)
-				router.Delete("/", handlers.DeleteCity)
-				router.Get("/info", handlers.GetCityInfo)
-				router.Route("/unitqitems", func(router chi.Router) {
-					router.Get("/", handlers.ListUnitQueueItem)
-					router.Post("/", handlers.QueueUnit)
-					router.With(internal.WithUnitQueueItemIDContext).Route(fmt.Sprintf("/{%s}", internal.ItemID), func(router chi.Router) {
-						router.Get("/", handlers.GetUnitQueueItem)
-					})
-				})
-				router.Route("/buildingqitems", func(router chi.Router) {
-					router.Get("/", handlers.ListBuildingQueueItems)
-					router.Post("/", handlers.QueueBuilding)
-					router.With(internal.WithBuildingQueueItemIDContext).Route(fmt.Sprintf("/{%s}", internal.ItemID), func(router chi.Router) {
-						router.Get("/", handlers.GetBuildingQueueItem)
-					})
-				})
-			})
-		})
-		router.Route("/movements", func(router chi.Router) {
-			router.Use(internal.WithPagination)
-			router.Get("/", handlers.ListMovements)
-			router.Post("/", handlers.StartMovement)
-
-			router.Route(fmt.Sprintf("/{%s}", internal.MovementID), func(router chi.Router) {
-				router.Use(internal.WithMovementIDContext)
-				router.Get("/", handlers.GetMovement)
-			})
-		})
-	})
+	worldRouters := make(map[string]func(chi.Router), len(worlds))
+	worldConfigs := make(map[string]*internal.ConfigHistory, len(worlds))
+	for _, world := range worlds {
+		database := internal.NewStickerioRepository(world.DatabaseHost)
+		applied, err := database.MigrateUp(ctx)
+		if err != nil {
+			log.Fatalf("could not migrate world %s database: %v", world.ID, err)
+		}
+		if applied > 0 {
+			log.Printf("applied %d migrations to world %s database", applied, world.ID)
+		}
+		clock := internal.NewClock()
+		configs, err := internal.NewConfigHistory(ctx, database, clock, world.ConfigPath)
+		if err != nil {
+			log.Fatalf("could not load world %s config: %v", world.ID, err)
+		}
+		eventSourcer := internal.NewEventSourcer(database, configs, clock)
+		go eventSourcer.StartEventsWorker(ctx, cfg.resyncPeriod)
+		handlers := internal.NewServerHandler(database, eventSourcer, configs, clock)
+		worldRouters[world.ID] = internal.WorldRoutes(handlers, cfg.adminToken)
+		worldConfigs[world.ID] = configs
+	}
+	go reloadConfigsOnHangup(ctx, worlds, worldConfigs)
+	router := internal.NewRouter(defaultWorldID, worldRouters)
 
 	server := &http.Server{
 		Addr:              ":" + cfg.port,
@@ -31,11 +31,15 @@ const (
 	movement          resourceType = "movement"
 	buildingqueueitem resourceType = "buildingqueueitem"
 	unitqueueitem     resourceType = "unitqueueitem"
+	alliance          resourceType = "alliance"
+	leaderboard       resourceType = "leaderboard"
 
 	cityShort              resourceTypeShort = "cit"
 	movementShort          resourceTypeShort = "mov"
 	buildingqueueitemShort resourceTypeShort = "bqi"
 	unitqueueitemShort     resourceTypeShort = "uqi"
+	allianceShort          resourceTypeShort = "ali"
+	leaderboardShort       resourceTypeShort = "lea"
 )
 
 var (
@@ -51,12 +55,16 @@ var (
 		movement:          {},
 		buildingqueueitem: {},
 		unitqueueitem:     {},
+		alliance:          {},
+		leaderboard:       {},
 	}
 	fromShortResourceType = map[resourceTypeShort]resourceType{
 		cityShort:              city,
 		movementShort:          movement,
 		buildingqueueitemShort: buildingqueueitem,
 		unitqueueitemShort:     unitqueueitem,
+		allianceShort:          alliance,
+		leaderboardShort:       leaderboard,
 	}
 )
 
@@ -71,6 +79,8 @@ var (
 		movement:          "/v1/movements",
 		buildingqueueitem: "/v1/cities/%s/buildingqitems",
 		unitqueueitem:     "/v1/cities/%s/unitqitems",
+		alliance:          "/v1/alliances",
+		leaderboard:       "/v1/leaderboards/%s",
 	}
 	methodFromCmd = map[commandType]string{
 		getcmd:    "GET",
@@ -194,6 +204,7 @@ One of the following is a valid object (and their shortened version):
 	movement (mov)
 	buildingqueueitem (bqi)
 	unitqueueitem (uqi)
+	alliance (ali)
 
 You will also have to either specify the movement ID or the city ID from which you want 
 to query as well as the ID of the building queue item, or unit queue item. For example:
@@ -201,8 +212,9 @@ to query as well as the ID of the building queue item, or unit queue item. For e
 %s get mov mov-id-321
 %s get bqi city-id-123 bqi-id-321
 %s get city city-id-123
+%s get ali alliance-id-123
 
-`, os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 	return errHelpRequested
 }
 
@@ -2,17 +2,23 @@ module github.com/luisferreira32/stickerio
 
 go 1.21.0
 
+require (
+	github.com/fergusstrange/embedded-postgres v1.25.0
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/google/go-cmp v0.6.0
+	github.com/google/uuid v1.5.0
+	github.com/lib/pq v1.10.9
+	modernc.org/sqlite v1.28.0
+)
+
 require (
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/glebarez/go-sqlite v1.22.0 // indirect
-	github.com/go-chi/chi/v5 v5.0.12 // indirect
-	github.com/google/go-cmp v0.6.0 // indirect
-	github.com/google/uuid v1.5.0 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	github.com/xi2/xz v0.0.0-20171230120015-48954b6210f8 // indirect
 	golang.org/x/sys v0.15.0 // indirect
 	modernc.org/libc v1.37.6 // indirect
 	modernc.org/mathutil v1.6.0 // indirect
 	modernc.org/memory v1.7.2 // indirect
-	modernc.org/sqlite v1.28.0 // indirect
 )
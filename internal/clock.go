@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the game time. Everything that stamps or releases events reads
+// it, so that a world can be fast-forwarded without waiting on the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// FakeClock stands still until it is advanced.
+type FakeClock struct {
+	lock *sync.Mutex
+	now  time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		lock: &sync.Mutex{},
+		now:  now,
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1_000_000, 0)
+	clock := NewFakeClock(start)
+	mustEqual(t, clock.Now(), start)
+	clock.Advance(90 * time.Second)
+	mustEqual(t, clock.Now(), start.Add(90*time.Second))
+}
+
+func TestEventSourcerFollowsTheClock(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+
+	// events are stamped with the time of the clock
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	events, err := repository.ListEvents(ctx, clock.Now().Unix())
+	mustNoErr(t, err)
+	mustEqual(t, len(events), 1)
+	mustEqual(t, events[0].epoch, tSec(clock.Now().Unix()))
+
+	// and only released once the clock gets to them, however long it takes on the wall clock
+	clock.Advance(200 * time.Second)
+	mustNoErr(t, inserter.QueueBuilding(ctx, "p1", &buildingQueueItem{id: "b1", cityID: "c1", targetLevel: 1, targetBuilding: "mines"}))
+	processQueued(t, eventSourcer)
+	upgradeSpeed := time.Duration(eventSourcer.configs.Latest().Buildings["mines"].UpgradeSpeed[0]) * time.Second
+	clock.Advance(upgradeSpeed - time.Second)
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	mustEqual(t, eventSourcer.inMemoryState.cityList["c1"].buildingsLevel["mines"], tBuildingLevel(0))
+	clock.Advance(time.Second)
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	mustEqual(t, eventSourcer.inMemoryState.cityList["c1"].buildingsLevel["mines"], tBuildingLevel(1))
+}
@@ -1,10 +1,17 @@
 package internal
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"os"
 	"sort"
+	"strings"
+	"sync"
+
+	api "github.com/luisferreira32/stickerio/api"
 )
 
 // NOTE: we take advantage of golang's defaults to false for units/resources
@@ -17,6 +24,10 @@ type buildingSpecs struct {
 	MaxLevel           tBuildingLevel         `json:"maxLevel"`
 	Units              map[tUnitName]bool     `json:"units"`
 	Resources          map[tResourceName]bool `json:"resources"`
+	RequiredBuildings  tBuildingsLevel        `json:"requiredBuildings"`
+	// Resources the merchants of the city can carry at once at the level of
+	// the index, summed over the buildings of the city.
+	MerchantCapacity []tResourceCount `json:"merchantCapacity"`
 }
 
 type unitSpecs struct {
@@ -25,53 +36,140 @@ type unitSpecs struct {
 	UnitCost               tResourcesCount                  `json:"cost"`
 	CombatStats            map[tUnitStatName]tUnitStatPower `json:"stats"`
 	CarryCapacity          tResourceCount                   `json:"carryCapacity"`
+	RequiredBuildings      tBuildingsLevel                  `json:"requiredBuildings"`
+}
+
+// Research is done once per player but paid for by the city that researches it.
+// Units and buildings unlocked by some research cannot be trained or built
+// until one of the researches unlocking them is done.
+type researchSpecs struct {
+	Cost              tResourcesCount           `json:"cost"`
+	DurationSec       tSec                      `json:"duration"`
+	RequiredBuildings tBuildingsLevel           `json:"requiredBuildings"`
+	RequiredResearch  []tResearchName           `json:"requiredResearch"`
+	UnlockedUnits     map[tUnitName]bool        `json:"unlocksUnits"`
+	UnlockedBuildings map[tBuildingName]bool    `json:"unlocksBuildings"`
+	StatMultipliers   map[tUnitStatName]float64 `json:"statMultipliers"`
+}
+
+// The world is generated from its seed rather than stored, so that every tile
+// of it can be worked out from the config alone. A world without terrains is an
+// endless plain, as every world was before there was a generator.
+type worldSpecs struct {
+	Seed int64 `json:"seed"`
+	// Locations go from -size to size on both axes, no size means no bounds.
+	Size tCoordinate `json:"size"`
+	// How many tiles apart the terrain changes, roughly the size of a patch.
+	FeatureSize    tCoordinate                          `json:"featureSize"`
+	DefaultTerrain tTerrainName                         `json:"defaultTerrain"`
+	Terrains       map[tTerrainName]terrainSpecs        `json:"terrains"`
+	ResourceFields map[tResourceName]resourceFieldSpecs `json:"resourceFields"`
+	Barbarians     barbarianSpecs                       `json:"barbarians"`
+}
+
+// Each terrain other than the default one comes in patches, the higher its
+// frequency the more of the world it covers. Cities cannot be placed on
+// impassable terrain, but movements still go across it in a straight line at
+// its movement cost: the detour around it.
+type terrainSpecs struct {
+	Frequency    float64 `json:"frequency"`
+	MovementCost float64 `json:"movementCost"`
+	Impassable   bool    `json:"impassable"`
+}
+
+// Resource fields come in patches like terrains do, a city on a field of a
+// resource has the trickle of that resource multiplied.
+type resourceFieldSpecs struct {
+	Frequency  float64 `json:"frequency"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Barbarian villages are scattered by the world generator over the locations
+// that can be settled, about one per frequency of them. They belong to no
+// player: they produce resources like any city of their building levels does,
+// and after being attacked their garrison regrows every interval up to what it
+// spawned with. Once left without defenders they can be conquered, if allowed.
+type barbarianSpecs struct {
+	Frequency        float64         `json:"frequency"`
+	Buildings        tBuildingsLevel `json:"buildings"`
+	Garrison         tUnitsCount     `json:"garrison"`
+	Regrowth         tUnitsCount     `json:"regrowth"`
+	RegrowthInterval tSec            `json:"regrowthInterval"`
+	Conquerable      bool            `json:"conquerable"`
+}
+
+// Merchants carry resources between cities without any units, for transports
+// and for the trades of the market, all at the same speed.
+type marketSpecs struct {
+	MerchantSpeed tSpeed        `json:"merchantSpeed"`
+	Exchange      exchangeSpecs `json:"exchange"`
+}
+
+// The exchange converts resources of a city into others right away, with no
+// player on the other side. Each pair has its own ratio, the resources to
+// receive for each one given, and the fee is the share of them it keeps. It
+// only opens to cities that meet its building requirements.
+type exchangeSpecs struct {
+	RequiredBuildings tBuildingsLevel                             `json:"requiredBuildings"`
+	Ratios            map[tResourceName]map[tResourceName]float64 `json:"ratios"`
+	Fee               float64                                     `json:"fee"`
 }
 
 type gameConfig struct {
 	Buildings           map[tBuildingName]buildingSpecs `json:"buildings"`
 	Units               map[tUnitName]unitSpecs         `json:"units"`
+	Research            map[tResearchName]researchSpecs `json:"research"`
 	ResourceTrickles    tResourcesCount                 `json:"resources"`
 	ForagingCoefficient float64
-	CombatEfficiency    float64
+	// The least share of their stats units bring to a battle, the rest is up
+	// to the dice.
+	CombatEfficiency float64 `json:"combatEfficiency"`
+	// Speeds up the whole world: durations are divided by it and resource
+	// trickles multiplied by it, so that the balance stays the same.
+	WorldSpeed float64 `json:"worldSpeed"`
+	// New players cannot be attacked for as long, unless they attack another
+	// player first.
+	BeginnerProtection tSec        `json:"beginnerProtection"`
+	Market             marketSpecs `json:"market"`
+	World              worldSpecs  `json:"world"`
 }
 
-var (
-	cfg gameConfig
+// Config is a game config loaded for a world, along with the pre-computations
+// that make the game logic easier. Every world holds its own.
+type Config struct {
+	gameConfig
+
+	version       int64
+	effectiveFrom tSec
+	sourceHash    string
 
 	// pre-computations
 	sortedSlowestUnits            []tUnitName
 	cumulativeResourceMultipliers map[tResourceName][]tBuildingName
 	cumulativeTrainingMultipliers map[tUnitName][]tBuildingName
-)
-
-func init() {
-	rawConfig, err := os.ReadFile("config.json") // TODO: simplify the path for configuration reading
-	if err != nil {
-		panic(err)
-	}
-
-	cfg = gameConfig{}
+	unitUnlockedBy                map[tUnitName][]tResearchName
+	buildingUnlockedBy            map[tBuildingName][]tResearchName
+	sortedTerrains                []tTerrainName
+	sortedResourceFields          []tResourceName
+	villages                      []coordinates
+	hash                          string
+}
 
-	err = json.Unmarshal(rawConfig, &cfg)
-	if err != nil {
-		panic(err)
-	}
+var (
+	errInvalidConfig = fmt.Errorf("invalid config")
+)
 
-	// TODO: basic checks for consistency
-	// * levels / costs / multipliers match
-	// * costs are done with existing resources
-	// * check if buildings define training multipliers if they train units
-	// * check if buildings define trickle multipliers if they produce resources
-	// * ensure all units have a value for each stat type (even if zero)
+func newConfig(c gameConfig) (*Config, error) {
+	cfg := &Config{gameConfig: c}
 
 	// NOTE: setup some pre-calculations for easier game logic
 
-	sortedSlowestUnits = make([]tUnitName, 0, len(cfg.Units))
+	cfg.sortedSlowestUnits = make([]tUnitName, 0, len(cfg.Units))
 	for k := range cfg.Units {
-		sortedSlowestUnits = append(sortedSlowestUnits, k)
+		cfg.sortedSlowestUnits = append(cfg.sortedSlowestUnits, k)
 	}
-	sort.Slice(sortedSlowestUnits, func(i, j int) bool {
-		return cfg.Units[sortedSlowestUnits[i]].UnitSpeed < cfg.Units[sortedSlowestUnits[j]].UnitSpeed
+	sort.Slice(cfg.sortedSlowestUnits, func(i, j int) bool {
+		return cfg.Units[cfg.sortedSlowestUnits[i]].UnitSpeed < cfg.Units[cfg.sortedSlowestUnits[j]].UnitSpeed
 	})
 
 	readOnlyResourceMultipliers := make(map[tResourceName][]tBuildingName, len(cfg.ResourceTrickles))
@@ -95,6 +193,807 @@ func init() {
 		}
 	}
 
-	// TODO efficiency range can come from config + future bonuses
-	cfg.CombatEfficiency = rand.Float64()*0.3 + 0.7
+	cfg.cumulativeResourceMultipliers = readOnlyResourceMultipliers
+	cfg.cumulativeTrainingMultipliers = readOnlyTrainingMultipliers
+
+	cfg.unitUnlockedBy = make(map[tUnitName][]tResearchName)
+	cfg.buildingUnlockedBy = make(map[tBuildingName][]tResearchName)
+	for researchKey, research := range cfg.Research {
+		for unitKey, unlocked := range research.UnlockedUnits {
+			if !unlocked {
+				continue
+			}
+			cfg.unitUnlockedBy[unitKey] = append(cfg.unitUnlockedBy[unitKey], researchKey)
+		}
+		for buildingKey, unlocked := range research.UnlockedBuildings {
+			if !unlocked {
+				continue
+			}
+			cfg.buildingUnlockedBy[buildingKey] = append(cfg.buildingUnlockedBy[buildingKey], researchKey)
+		}
+	}
+
+	// NOTE: the default terrain is whatever is left after the other terrains
+	cfg.sortedTerrains = make([]tTerrainName, 0, len(cfg.World.Terrains))
+	for _, terrainName := range sortedKeys(cfg.World.Terrains) {
+		if terrainName == cfg.World.DefaultTerrain {
+			continue
+		}
+		cfg.sortedTerrains = append(cfg.sortedTerrains, terrainName)
+	}
+	cfg.sortedResourceFields = sortedKeys(cfg.World.ResourceFields)
+
+	// NOTE: hash the config as it is served, so that any rebalance of the world changes it
+	hashedConfig, err := json.Marshal(gameConfigToAPIModel(cfg.gameConfig))
+	if err != nil {
+		return nil, err
+	}
+	cfg.hash = fmt.Sprintf("%x", sha256.Sum256(hashedConfig))
+	return cfg, nil
+}
+
+func parseGameConfig(path string) (gameConfig, error) {
+	rawConfig, err := os.ReadFile(path)
+	if err != nil {
+		return gameConfig{}, err
+	}
+	return parseRawGameConfig(rawConfig)
+}
+
+func parseRawGameConfig(rawConfig []byte) (gameConfig, error) {
+	c := gameConfig{}
+	err := json.Unmarshal(rawConfig, &c)
+	if err != nil {
+		return gameConfig{}, fmt.Errorf("%w: %s", errInvalidConfig, err.Error())
+	}
+	c.setDefaults()
+
+	return c, c.Validate()
+}
+
+type configRepository interface {
+	ListConfigVersions(ctx context.Context) ([]*dbConfigVersion, error)
+	InsertConfigVersion(ctx context.Context, v *dbConfigVersion) error
+}
+
+// ConfigHistory keeps every config version a world ever had, each effective
+// from the epoch it was activated on. Events are always processed with the
+// version that was active at their epoch, so that a rebalance does not change
+// the outcome of the past on a re-sync.
+type ConfigHistory struct {
+	repository configRepository
+	clock      Clock
+
+	lock     *sync.RWMutex
+	versions []*Config
+	// NOTE: generating villages goes through the whole world, versions that do
+	// not reshape it share them
+	villagesByWorld map[string][]coordinates
+}
+
+// NewConfigHistory loads the stored config versions of a world and activates
+// the config at the given path if it differs from the latest one.
+func NewConfigHistory(ctx context.Context, repository configRepository, clock Clock, path string) (*ConfigHistory, error) {
+	dbVersions, err := repository.ListConfigVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &ConfigHistory{
+		repository:      repository,
+		clock:           clock,
+		lock:            &sync.RWMutex{},
+		versions:        make([]*Config, 0, len(dbVersions)),
+		villagesByWorld: make(map[string][]coordinates),
+	}
+	for _, dbVersion := range dbVersions {
+		c := gameConfig{}
+		err = json.Unmarshal([]byte(dbVersion.config), &c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: version %d: %s", errInvalidConfig, dbVersion.version, err.Error())
+		}
+		c.setDefaults()
+		cfg, err := newConfig(c)
+		if err != nil {
+			return nil, err
+		}
+		cfg.villages = h.villagesOf(cfg)
+		cfg.version = dbVersion.version
+		cfg.effectiveFrom = tSec(dbVersion.effectiveFrom)
+		cfg.sourceHash = dbVersion.sourceHash
+		h.versions = append(h.versions, cfg)
+	}
+
+	_, err = h.Reload(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Reload activates the config at the given path.
+func (h *ConfigHistory) Reload(ctx context.Context, path string) (*Config, error) {
+	rawConfig, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return h.Activate(ctx, rawConfig)
+}
+
+// Activate validates a raw config and makes it effective from now on. Activating
+// the same config as the latest one is a no-op.
+func (h *ConfigHistory) Activate(ctx context.Context, rawConfig []byte) (*Config, error) {
+	c, err := parseRawGameConfig(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	sourceHash := fmt.Sprintf("%x", sha256.Sum256(rawConfig))
+	var latest *Config
+	if len(h.versions) > 0 {
+		latest = h.versions[len(h.versions)-1]
+	}
+	if latest != nil && latest.sourceHash == sourceHash {
+		return latest, nil
+	}
+
+	// an unseeded world keeps the seed it already had, a rebalance must not move mountains
+	if c.World.Seed == 0 && latest != nil {
+		c.World.Seed = latest.World.Seed
+	}
+	if latest != nil {
+		err = c.validateSuccessor(latest.gameConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.World.Seed == 0 {
+		c.World.Seed = rand.Int63()
+	}
+
+	cfg, err := newConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg.villages = h.villagesOf(cfg)
+	cfg.version = 1
+	cfg.effectiveFrom = tSec(h.clock.Now().Unix())
+	cfg.sourceHash = sourceHash
+	if latest != nil {
+		cfg.version = latest.version + 1
+		// NOTE: never go back in time, events were already processed with the latest version
+		if cfg.effectiveFrom < latest.effectiveFrom {
+			cfg.effectiveFrom = latest.effectiveFrom
+		}
+	}
+
+	// the stored config is the parsed one, so that generated values survive restarts
+	storedConfig, err := json.Marshal(cfg.gameConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = h.repository.InsertConfigVersion(ctx, &dbConfigVersion{
+		version:       cfg.version,
+		effectiveFrom: int64(cfg.effectiveFrom),
+		sourceHash:    cfg.sourceHash,
+		config:        string(storedConfig),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.versions = append(h.versions, cfg)
+	return cfg, nil
+}
+
+// villagesOf are the villages of the world of a version, generated only for
+// worlds no other version had. The caller holds the lock or has the history to
+// itself.
+func (h *ConfigHistory) villagesOf(cfg *Config) []coordinates {
+	key := cfg.villagesKey()
+	villages, ok := h.villagesByWorld[key]
+	if !ok {
+		villages = cfg.generateVillages()
+		h.villagesByWorld[key] = villages
+	}
+	return villages
+}
+
+// until is every config version in effect at some point up to the given
+// epoch, the first version always is.
+func (h *ConfigHistory) until(epoch tSec) []*Config {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	i := sort.Search(len(h.versions), func(i int) bool { return h.versions[i].effectiveFrom > epoch })
+	return h.versions[:max(i, 1)]
+}
+
+// Latest is the config version in effect right now.
+func (h *ConfigHistory) Latest() *Config {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.versions[len(h.versions)-1]
+}
+
+// At is the config version in effect at the given epoch. Anything before the
+// first version uses the first version.
+func (h *ConfigHistory) At(epoch tSec) *Config {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	i := sort.Search(len(h.versions), func(i int) bool { return h.versions[i].effectiveFrom > epoch })
+	if i == 0 {
+		return h.versions[0]
+	}
+	return h.versions[i-1]
+}
+
+// NOTE: defaults also apply to stored versions that predate the setting.
+func (c *gameConfig) setDefaults() {
+	// omitting the world speed means a regular world
+	if c.WorldSpeed == 0 {
+		c.WorldSpeed = 1
+	}
+	// omitting the combat efficiency means units bring most of their stats
+	if c.CombatEfficiency == 0 {
+		c.CombatEfficiency = 0.85
+	}
+	// omitting the feature size means terrain changes from tile to tile
+	if c.World.FeatureSize == 0 {
+		c.World.FeatureSize = 1
+	}
+}
+
+// CheckConfig reads and validates the config file without loading it, so that
+// configs can be linted offline.
+func CheckConfig(path string) error {
+	_, err := parseGameConfig(path)
+	return err
+}
+
+// Validate goes through the whole config and reports every problem found at
+// once, instead of failing on the first one (or panicking mid-game).
+func (c gameConfig) Validate() error {
+	problems := make([]string, 0)
+	report := func(format string, a ...any) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+	checkCost := func(owner string, cost tResourcesCount) {
+		for resourceName := range cost {
+			if _, ok := c.ResourceTrickles[resourceName]; !ok {
+				report("%s: cost uses unknown resource %s", owner, resourceName)
+			}
+		}
+	}
+	checkRequiredBuildings := func(owner string, required tBuildingsLevel) {
+		for buildingName, level := range required {
+			building, ok := c.Buildings[buildingName]
+			switch {
+			case !ok:
+				report("%s: requires unknown building %s", owner, buildingName)
+			case level > building.MaxLevel:
+				report("%s: requires %s at level %d but its max level is %d", owner, buildingName, level, building.MaxLevel)
+			}
+		}
+	}
+
+	if c.WorldSpeed <= 0 {
+		report("worldSpeed must be positive")
+	}
+	if c.CombatEfficiency <= 0 || c.CombatEfficiency > 1 {
+		report("combatEfficiency must be in (0, 1]")
+	}
+	if c.BeginnerProtection < 0 {
+		report("beginnerProtection must not be negative")
+	}
+
+	if c.World.Size < 0 {
+		report("world: size must not be negative")
+	}
+	if c.World.FeatureSize <= 0 {
+		report("world: featureSize must be positive")
+	}
+	if _, ok := c.World.Terrains[c.World.DefaultTerrain]; len(c.World.Terrains) > 0 && !ok {
+		report("world: unknown default terrain %s", c.World.DefaultTerrain)
+	}
+	if c.World.Terrains[c.World.DefaultTerrain].Impassable {
+		report("world: default terrain %s cannot be impassable", c.World.DefaultTerrain)
+	}
+	for terrainName, terrain := range c.World.Terrains {
+		owner := fmt.Sprintf("terrain %s", terrainName)
+		if terrain.Frequency < 0 || terrain.Frequency > 1 {
+			report("%s: frequency must be between 0 and 1", owner)
+		}
+		if terrain.MovementCost <= 0 {
+			report("%s: movementCost must be positive", owner)
+		}
+	}
+	if barbarians := c.World.Barbarians; barbarians.Frequency != 0 {
+		owner := "barbarians"
+		if barbarians.Frequency < 0 || barbarians.Frequency > 1 {
+			report("%s: frequency must be between 0 and 1", owner)
+		}
+		if c.World.Size <= 0 {
+			report("%s: villages need a world size", owner)
+		}
+		if barbarians.RegrowthInterval <= 0 {
+			report("%s: regrowthInterval must be positive", owner)
+		}
+		for unitName := range barbarians.Garrison {
+			if _, ok := c.Units[unitName]; !ok {
+				report("%s: garrison has unknown unit %s", owner, unitName)
+			}
+		}
+		for unitName := range barbarians.Regrowth {
+			if _, ok := c.Units[unitName]; !ok {
+				report("%s: regrowth has unknown unit %s", owner, unitName)
+			}
+		}
+		for buildingName, level := range barbarians.Buildings {
+			building, ok := c.Buildings[buildingName]
+			switch {
+			case !ok:
+				report("%s: unknown building %s", owner, buildingName)
+			case level < 0 || level > building.MaxLevel:
+				report("%s: %s at level %d but its max level is %d", owner, buildingName, level, building.MaxLevel)
+			}
+		}
+	}
+	for resourceName, field := range c.World.ResourceFields {
+		owner := fmt.Sprintf("resource field %s", resourceName)
+		if _, ok := c.ResourceTrickles[resourceName]; !ok {
+			report("%s: unknown resource", owner)
+		}
+		if field.Frequency < 0 || field.Frequency > 1 {
+			report("%s: frequency must be between 0 and 1", owner)
+		}
+		if field.Multiplier <= 0 {
+			report("%s: multiplier must be positive", owner)
+		}
+	}
+
+	statNames := make(map[tUnitStatName]struct{})
+	for _, unit := range c.Units {
+		for statName := range unit.CombatStats {
+			statNames[statName] = struct{}{}
+		}
+	}
+
+	for unitName, unit := range c.Units {
+		owner := fmt.Sprintf("unit %s", unitName)
+		if unit.UnitSpeed <= 0 {
+			report("%s: speed must be positive", owner)
+		}
+		if unit.UnitProductionSpeedSec < 0 {
+			report("%s: productionSpeed must not be negative", owner)
+		}
+		checkCost(owner, unit.UnitCost)
+		for statName := range statNames {
+			if _, ok := unit.CombatStats[statName]; !ok {
+				report("%s: missing %s stat", owner, statName)
+			}
+		}
+		checkRequiredBuildings(owner, unit.RequiredBuildings)
+	}
+
+	hasMerchants := false
+	for buildingName, building := range c.Buildings {
+		owner := fmt.Sprintf("building %s", buildingName)
+		if building.MaxLevel <= 0 {
+			report("%s: maxLevel must be positive", owner)
+		}
+		if len(building.UpgradeCost) != int(building.MaxLevel) {
+			report("%s: cost has %d levels, expected %d", owner, len(building.UpgradeCost), building.MaxLevel)
+		}
+		if len(building.UpgradeSpeed) != int(building.MaxLevel) {
+			report("%s: upgradeSpeed has %d levels, expected %d", owner, len(building.UpgradeSpeed), building.MaxLevel)
+		}
+		for level, cost := range building.UpgradeCost {
+			checkCost(fmt.Sprintf("%s level %d", owner, level+1), cost)
+		}
+		for level, upgradeSpeed := range building.UpgradeSpeed {
+			if upgradeSpeed < 0 {
+				report("%s level %d: upgradeSpeed must not be negative", owner, level+1)
+			}
+		}
+		trainsUnits := false
+		for unitName, trains := range building.Units {
+			if _, ok := c.Units[unitName]; !ok {
+				report("%s: trains unknown unit %s", owner, unitName)
+			}
+			trainsUnits = trainsUnits || trains
+		}
+		// the multipliers include level 0
+		if trainsUnits && len(building.TrainingMultiplier) != int(building.MaxLevel)+1 {
+			report("%s: trainingMultiplier has %d levels, expected %d", owner, len(building.TrainingMultiplier), building.MaxLevel+1)
+		}
+		producesResources := false
+		for resourceName, produces := range building.Resources {
+			if _, ok := c.ResourceTrickles[resourceName]; !ok {
+				report("%s: produces unknown resource %s", owner, resourceName)
+			}
+			producesResources = producesResources || produces
+		}
+		if producesResources && len(building.ResourceMultiplier) != int(building.MaxLevel)+1 {
+			report("%s: resourceMultiplier has %d levels, expected %d", owner, len(building.ResourceMultiplier), building.MaxLevel+1)
+		}
+		if len(building.MerchantCapacity) > 0 {
+			hasMerchants = true
+			if len(building.MerchantCapacity) != int(building.MaxLevel)+1 {
+				report("%s: merchantCapacity has %d levels, expected %d", owner, len(building.MerchantCapacity), building.MaxLevel+1)
+			}
+		}
+		for _, capacity := range building.MerchantCapacity {
+			if capacity < 0 {
+				report("%s: merchantCapacity must not be negative", owner)
+				break
+			}
+		}
+		checkRequiredBuildings(owner, building.RequiredBuildings)
+		// a building that requires itself, however indirectly, can never be built
+		if requiresBuilding(c.Buildings, buildingName, buildingName, make(map[tBuildingName]struct{})) {
+			report("%s: requires itself", owner)
+		}
+	}
+	if hasMerchants && c.Market.MerchantSpeed <= 0 {
+		report("market: merchantSpeed must be positive")
+	}
+	checkRequiredBuildings("market exchange", c.Market.Exchange.RequiredBuildings)
+	for from, ratios := range c.Market.Exchange.Ratios {
+		if _, ok := c.ResourceTrickles[from]; !ok {
+			report("market exchange: exchanges unknown resource %s", from)
+		}
+		for to, ratio := range ratios {
+			if _, ok := c.ResourceTrickles[to]; !ok {
+				report("market exchange: exchanges unknown resource %s", to)
+			}
+			if to == from || ratio <= 0 {
+				report("market exchange: invalid ratio %v from %s to %s", ratio, from, to)
+			}
+		}
+	}
+	if c.Market.Exchange.Fee < 0 || c.Market.Exchange.Fee >= 1 {
+		report("market exchange: fee must be in [0, 1)")
+	}
+
+	for researchName, research := range c.Research {
+		owner := fmt.Sprintf("research %s", researchName)
+		checkCost(owner, research.Cost)
+		checkRequiredBuildings(owner, research.RequiredBuildings)
+		for _, requiredResearch := range research.RequiredResearch {
+			if _, ok := c.Research[requiredResearch]; !ok {
+				report("%s: requires invalid research %s", owner, requiredResearch)
+			}
+		}
+		// a research that requires itself, however indirectly, can never be done
+		if requiresResearch(c.Research, researchName, researchName, make(map[tResearchName]struct{})) {
+			report("%s: requires itself", owner)
+		}
+		for unitName := range research.UnlockedUnits {
+			if _, ok := c.Units[unitName]; !ok {
+				report("%s: unlocks unknown unit %s", owner, unitName)
+			}
+		}
+		for buildingName := range research.UnlockedBuildings {
+			if _, ok := c.Buildings[buildingName]; !ok {
+				report("%s: unlocks unknown building %s", owner, buildingName)
+			}
+		}
+		for statName := range research.StatMultipliers {
+			if _, ok := statNames[statName]; !ok {
+				report("%s: multiplies unknown stat %s", owner, statName)
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w:\n  - %s", errInvalidConfig, strings.Join(problems, "\n  - "))
+}
+
+// validateSuccessor reports what a config cannot change from the one it
+// replaces: the world and the cities in it were already built with that one.
+// Cities may be at any level up to the previous max level, lowering it would
+// leave them past the end of the multipliers.
+func (c gameConfig) validateSuccessor(previous gameConfig) error {
+	problems := make([]string, 0)
+	report := func(format string, a ...any) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	if c.World.Seed != previous.World.Seed {
+		report("world: seed cannot change from %d to %d, the terrain under the cities would", previous.World.Seed, c.World.Seed)
+	}
+	for buildingName, building := range c.Buildings {
+		previousBuilding, ok := previous.Buildings[buildingName]
+		if ok && building.MaxLevel < previousBuilding.MaxLevel {
+			report("building %s: maxLevel cannot go down from %d to %d", buildingName, previousBuilding.MaxLevel, building.MaxLevel)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w:\n  - %s", errInvalidConfig, strings.Join(problems, "\n  - "))
+}
+
+// Every duration of the game goes through the world speed.
+func (cfg *Config) scaledDuration(durationSec tSec) tSec {
+	return tSec(float64(durationSec) / cfg.WorldSpeed)
+}
+
+// A unit can only be trained in a city that has at least one building able to
+// train it, besides any other building levels the unit requires.
+func (cfg *Config) checkUnitRequirements(unitType tUnitName, buildingsLevel tBuildingsLevel) error {
+	trainable := false
+	for _, buildingKey := range cfg.cumulativeTrainingMultipliers[unitType] {
+		trainable = trainable || buildingsLevel[buildingKey] >= 1
+	}
+	if !trainable {
+		return fmt.Errorf("no building to train %s", unitType)
+	}
+	return checkRequiredBuildings(cfg.Units[unitType].RequiredBuildings, buildingsLevel)
+}
+
+func (cfg *Config) checkBuildingRequirements(buildingName tBuildingName, buildingsLevel tBuildingsLevel) error {
+	return checkRequiredBuildings(cfg.Buildings[buildingName].RequiredBuildings, buildingsLevel)
+}
+
+// The merchant capacity of a city adds up over all of its buildings.
+func (cfg *Config) merchantCapacity(buildingsLevel tBuildingsLevel) tResourceCount {
+	var capacity tResourceCount
+	for buildingName, level := range buildingsLevel {
+		merchantCapacity := cfg.Buildings[buildingName].MerchantCapacity
+		if int(level) < len(merchantCapacity) {
+			capacity += merchantCapacity[level]
+		}
+	}
+	return capacity
+}
+
+// exchange converts resources at the ratio of the pair and takes its fee out of
+// the resources received, rounded down.
+func (cfg *Config) exchange(from, to tResourceName, count tResourceCount) (tResourceCount, error) {
+	ratio, ok := cfg.Market.Exchange.Ratios[from][to]
+	if !ok {
+		return 0, fmt.Errorf("cannot exchange %s for %s", from, to)
+	}
+	if count <= 0 {
+		return 0, fmt.Errorf("nothing to exchange")
+	}
+	received := tResourceCount(float64(count) * ratio * (1 - cfg.Market.Exchange.Fee))
+	if received <= 0 {
+		return 0, fmt.Errorf("too few %s to receive any %s", from, to)
+	}
+	return received, nil
+}
+
+// requiresResearch tells whether a research requires another, directly or
+// through the research it requires in turn.
+func requiresResearch(research map[tResearchName]researchSpecs, researchName, required tResearchName, visited map[tResearchName]struct{}) bool {
+	for _, requiredResearch := range research[researchName].RequiredResearch {
+		if requiredResearch == required {
+			return true
+		}
+		if _, ok := visited[requiredResearch]; ok {
+			continue
+		}
+		visited[requiredResearch] = struct{}{}
+		if requiresResearch(research, requiredResearch, required, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresBuilding tells whether a building requires another, directly or
+// through the buildings it requires in turn.
+func requiresBuilding(buildings map[tBuildingName]buildingSpecs, buildingName, required tBuildingName, visited map[tBuildingName]struct{}) bool {
+	for requiredBuilding := range buildings[buildingName].RequiredBuildings {
+		if requiredBuilding == required {
+			return true
+		}
+		if _, ok := visited[requiredBuilding]; ok {
+			continue
+		}
+		visited[requiredBuilding] = struct{}{}
+		if requiresBuilding(buildings, requiredBuilding, required, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRequiredBuildings(required tBuildingsLevel, buildingsLevel tBuildingsLevel) error {
+	buildingNames := make([]tBuildingName, 0, len(required))
+	for buildingName := range required {
+		buildingNames = append(buildingNames, buildingName)
+	}
+	sort.Slice(buildingNames, func(i, j int) bool { return buildingNames[i] < buildingNames[j] })
+	for _, buildingName := range buildingNames {
+		if buildingsLevel[buildingName] < required[buildingName] {
+			return fmt.Errorf("requires %s at level %d", buildingName, required[buildingName])
+		}
+	}
+	return nil
+}
+
+// The requirements are sorted by name so that the tech graph is stable between calls.
+func requirementsToAPIModel(c *Config) api.V1Requirements {
+	requirements := api.V1Requirements{
+		Units:     make([]api.V1Requirement, 0, len(c.Units)),
+		Buildings: make([]api.V1Requirement, 0, len(c.Buildings)),
+		Research:  make([]api.V1Requirement, 0, len(c.Research)),
+	}
+	for unitName, unit := range c.Units {
+		requirements.Units = append(requirements.Units, api.V1Requirement{
+			Name:      string(unitName),
+			Buildings: toUntypedMap(unit.RequiredBuildings),
+			Research:  researchNames(c.unitUnlockedBy[unitName]),
+		})
+	}
+	for buildingName, building := range c.Buildings {
+		requirements.Buildings = append(requirements.Buildings, api.V1Requirement{
+			Name:      string(buildingName),
+			Buildings: toUntypedMap(building.RequiredBuildings),
+			Research:  researchNames(c.buildingUnlockedBy[buildingName]),
+		})
+	}
+	for researchName, research := range c.Research {
+		requirements.Research = append(requirements.Research, api.V1Requirement{
+			Name:      string(researchName),
+			Buildings: toUntypedMap(research.RequiredBuildings),
+			Research:  researchNames(research.RequiredResearch),
+		})
+	}
+	for _, r := range [][]api.V1Requirement{requirements.Units, requirements.Buildings, requirements.Research} {
+		sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+	}
+	return requirements
+}
+
+func researchNames(research []tResearchName) []string {
+	names := make([]string, len(research))
+	for i := 0; i < len(research); i++ {
+		names[i] = string(research[i])
+	}
+	sort.Strings(names)
+	return names
+}
+
+func gameConfigToAPIModel(c gameConfig) api.V1GameConfig {
+	gameConfig := api.V1GameConfig{
+		Units:                 make([]api.V1UnitSpecs, 0, len(c.Units)),
+		Buildings:             make([]api.V1BuildingSpecs, 0, len(c.Buildings)),
+		Research:              make([]api.V1ResearchSpecs, 0, len(c.Research)),
+		ResourceTrickles:      toUntypedMap(c.ResourceTrickles),
+		ForagingCoefficient:   c.ForagingCoefficient,
+		CombatEfficiency:      c.CombatEfficiency,
+		WorldSpeed:            c.WorldSpeed,
+		BeginnerProtectionSec: int64(c.BeginnerProtection),
+		Market: api.V1MarketSpecs{
+			MerchantSpeed: float64(c.Market.MerchantSpeed),
+			Exchange: api.V1ExchangeSpecs{
+				RequiredBuildings: toUntypedMap(c.Market.Exchange.RequiredBuildings),
+				Rates:             make([]api.V1ExchangeRate, 0),
+				Fee:               c.Market.Exchange.Fee,
+			},
+		},
+		World: api.V1WorldSpecs{
+			Seed:           c.World.Seed,
+			Size:           int32(c.World.Size),
+			FeatureSize:    int32(c.World.FeatureSize),
+			DefaultTerrain: string(c.World.DefaultTerrain),
+			Terrains:       make([]api.V1TerrainSpecs, 0, len(c.World.Terrains)),
+			ResourceFields: make([]api.V1ResourceFieldSpecs, 0, len(c.World.ResourceFields)),
+			Barbarians: api.V1BarbarianSpecs{
+				Frequency:           c.World.Barbarians.Frequency,
+				Buildings:           toUntypedMap(c.World.Barbarians.Buildings),
+				Garrison:            toUntypedMap(c.World.Barbarians.Garrison),
+				Regrowth:            toUntypedMap(c.World.Barbarians.Regrowth),
+				RegrowthIntervalSec: int64(c.World.Barbarians.RegrowthInterval),
+				Conquerable:         c.World.Barbarians.Conquerable,
+			},
+		},
+	}
+	for unitName, unit := range c.Units {
+		gameConfig.Units = append(gameConfig.Units, api.V1UnitSpecs{
+			Name:               string(unitName),
+			Speed:              float64(unit.UnitSpeed),
+			ProductionSpeedSec: int64(unit.UnitProductionSpeedSec),
+			Cost:               toUntypedMap(unit.UnitCost),
+			Stats:              toUntypedMap(unit.CombatStats),
+			CarryCapacity:      int64(unit.CarryCapacity),
+			RequiredBuildings:  toUntypedMap(unit.RequiredBuildings),
+		})
+	}
+	for buildingName, building := range c.Buildings {
+		upgradeCost := make([]map[string]int64, len(building.UpgradeCost))
+		for i := 0; i < len(building.UpgradeCost); i++ {
+			upgradeCost[i] = toUntypedMap(building.UpgradeCost[i])
+		}
+		upgradeSpeed := make([]int64, len(building.UpgradeSpeed))
+		for i := 0; i < len(building.UpgradeSpeed); i++ {
+			upgradeSpeed[i] = int64(building.UpgradeSpeed[i])
+		}
+		merchantCapacity := make([]int64, len(building.MerchantCapacity))
+		for i := 0; i < len(building.MerchantCapacity); i++ {
+			merchantCapacity[i] = int64(building.MerchantCapacity[i])
+		}
+		gameConfig.Buildings = append(gameConfig.Buildings, api.V1BuildingSpecs{
+			Name:               string(buildingName),
+			MaxLevel:           int64(building.MaxLevel),
+			UpgradeCost:        upgradeCost,
+			UpgradeSpeedSec:    upgradeSpeed,
+			ResourceMultiplier: append(make([]float64, 0, len(building.ResourceMultiplier)), building.ResourceMultiplier...),
+			TrainingMultiplier: append(make([]float64, 0, len(building.TrainingMultiplier)), building.TrainingMultiplier...),
+			Units:              enabledKeys(building.Units),
+			Resources:          enabledKeys(building.Resources),
+			RequiredBuildings:  toUntypedMap(building.RequiredBuildings),
+			MerchantCapacity:   merchantCapacity,
+		})
+	}
+	for researchName, research := range c.Research {
+		statMultipliers := make(map[string]float64, len(research.StatMultipliers))
+		for statName, multiplier := range research.StatMultipliers {
+			statMultipliers[string(statName)] = multiplier
+		}
+		gameConfig.Research = append(gameConfig.Research, api.V1ResearchSpecs{
+			Name:              string(researchName),
+			Cost:              toUntypedMap(research.Cost),
+			DurationSec:       int64(research.DurationSec),
+			RequiredBuildings: toUntypedMap(research.RequiredBuildings),
+			RequiredResearch:  researchNames(research.RequiredResearch),
+			UnlocksUnits:      enabledKeys(research.UnlockedUnits),
+			UnlocksBuildings:  enabledKeys(research.UnlockedBuildings),
+			StatMultipliers:   statMultipliers,
+		})
+	}
+	for _, terrainName := range sortedKeys(c.World.Terrains) {
+		terrain := c.World.Terrains[terrainName]
+		gameConfig.World.Terrains = append(gameConfig.World.Terrains, api.V1TerrainSpecs{
+			Name:         string(terrainName),
+			Frequency:    terrain.Frequency,
+			MovementCost: terrain.MovementCost,
+			Impassable:   terrain.Impassable,
+		})
+	}
+	for _, resourceName := range sortedKeys(c.World.ResourceFields) {
+		field := c.World.ResourceFields[resourceName]
+		gameConfig.World.ResourceFields = append(gameConfig.World.ResourceFields, api.V1ResourceFieldSpecs{
+			Resource:   string(resourceName),
+			Frequency:  field.Frequency,
+			Multiplier: field.Multiplier,
+		})
+	}
+	for _, from := range sortedKeys(c.Market.Exchange.Ratios) {
+		for _, to := range sortedKeys(c.Market.Exchange.Ratios[from]) {
+			gameConfig.Market.Exchange.Rates = append(gameConfig.Market.Exchange.Rates, api.V1ExchangeRate{
+				From:  string(from),
+				To:    string(to),
+				Ratio: c.Market.Exchange.Ratios[from][to],
+			})
+		}
+	}
+	sort.Slice(gameConfig.Units, func(i, j int) bool { return gameConfig.Units[i].Name < gameConfig.Units[j].Name })
+	sort.Slice(gameConfig.Buildings, func(i, j int) bool { return gameConfig.Buildings[i].Name < gameConfig.Buildings[j].Name })
+	sort.Slice(gameConfig.Research, func(i, j int) bool { return gameConfig.Research[i].Name < gameConfig.Research[j].Name })
+	return gameConfig
+}
+
+func enabledKeys[K ~string](m map[K]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k, enabled := range m {
+		if !enabled {
+			continue
+		}
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	return keys
 }
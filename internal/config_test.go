@@ -1 +1,357 @@
 package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourcesAccrueAtTheRatesOfTheirVersion(t *testing.T) {
+	v1 := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	v2 := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	v2.WorldSpeed = 2
+	v2.effectiveFrom = 100
+
+	// the rebalance does not apply to the time before it
+	c := &city{buildingsLevel: make(tBuildingsLevel), resourceBase: make(tResourcesCount)}
+	mustNoErr(t, reCityCalculateResources([]*Config{v1, v2}, 200, tResourcesCount{}, c))
+	mustEqual(t, c.resourceBase, tResourcesCount{"sticks": 600, "circles": 300})
+	mustEqual(t, c.resourceEpoch, tSec(200))
+
+	// nor to the time after the last recalculation
+	c = &city{buildingsLevel: make(tBuildingsLevel), resourceBase: make(tResourcesCount), resourceEpoch: 150}
+	mustNoErr(t, reCityCalculateResources([]*Config{v1, v2}, 200, tResourcesCount{"sticks": 100}, c))
+	mustEqual(t, c.resourceBase, tResourcesCount{"sticks": 100, "circles": 100})
+}
+
+// rebalancedConfig is the default config with a rebalance of choice.
+func rebalancedConfig(t *testing.T, rebalance func(c map[string]any)) []byte {
+	t.Helper()
+	rawConfig, err := os.ReadFile("../config.json")
+	mustNoErr(t, err)
+	c := make(map[string]any)
+	mustNoErr(t, json.Unmarshal(rawConfig, &c))
+	rebalance(c)
+	rawConfig, err = json.Marshal(c)
+	mustNoErr(t, err)
+	return rawConfig
+}
+
+func TestConfigHistory(t *testing.T) {
+	ctx := context.Background()
+	repository := newInMemoryRepository()
+	clock := NewFakeClock(time.Unix(1_000_000, 0))
+	h, err := NewConfigHistory(ctx, repository, clock, "../config.json")
+	mustNoErr(t, err)
+	v1 := h.Latest()
+	mustEqual(t, v1.version, int64(1))
+	mustEqual(t, v1.effectiveFrom, tSec(1_000_000))
+
+	// the same config again is no new version
+	rawConfig, err := os.ReadFile("../config.json")
+	mustNoErr(t, err)
+	cfg, err := h.Activate(ctx, rawConfig)
+	mustNoErr(t, err)
+	if cfg != v1 {
+		t.Fatalf("expected the latest version back, got version %d", cfg.version)
+	}
+	_, err = h.Activate(ctx, []byte("{"))
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("got %v, want %v", err, errInvalidConfig)
+	}
+
+	// a rebalance is effective from the epoch it is activated on
+	clock.Advance(100 * time.Second)
+	v2, err := h.Activate(ctx, rebalancedConfig(t, func(c map[string]any) { c["worldSpeed"] = 2 }))
+	mustNoErr(t, err)
+	mustEqual(t, v2.version, int64(2))
+	mustEqual(t, v2.effectiveFrom, tSec(1_000_100))
+	if h.Latest() != v2 || h.At(0) != v1 || h.At(1_000_099) != v1 || h.At(1_000_100) != v2 || h.At(2_000_000) != v2 {
+		t.Fatal("expected each epoch to get the version in effect at it")
+	}
+	mustEqual(t, len(h.until(1_000_099)), 1)
+	mustEqual(t, len(h.until(1_000_100)), 2)
+
+	// going back to a previous config is a new version that plays the same
+	clock.Advance(100 * time.Second)
+	v3, err := h.Activate(ctx, rawConfig)
+	mustNoErr(t, err)
+	mustEqual(t, v3.version, int64(3))
+	mustEqual(t, v3.CombatEfficiency, v1.CombatEfficiency)
+	mustEqual(t, v3.World.Seed, v1.World.Seed)
+	mustEqual(t, v3.hash, v1.hash)
+
+	// the versions survive restarts as they were
+	restarted, err := NewConfigHistory(ctx, repository, clock, "../config.json")
+	mustNoErr(t, err)
+	for _, v := range []*Config{v1, v2, v3} {
+		got := restarted.At(v.effectiveFrom)
+		mustEqual(t, got.version, v.version)
+		mustEqual(t, got.hash, v.hash)
+		mustEqual(t, got.CombatEfficiency, v.CombatEfficiency)
+	}
+	mustEqual(t, restarted.Latest().version, int64(3))
+}
+
+func TestConfigHistoryVillages(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Unix(1_000_000, 0))
+	h, err := NewConfigHistory(ctx, newInMemoryRepository(), clock, "../config.json")
+	mustNoErr(t, err)
+	v1 := h.Latest()
+	if len(v1.villages) == 0 {
+		t.Fatal("expected the default world to have villages")
+	}
+
+	// a rebalance that leaves the world be does not generate it again
+	clock.Advance(100 * time.Second)
+	v2, err := h.Activate(ctx, rebalancedConfig(t, func(c map[string]any) { c["worldSpeed"] = 2 }))
+	mustNoErr(t, err)
+	if &v2.villages[0] != &v1.villages[0] {
+		t.Fatal("expected the versions to share the villages of their world")
+	}
+
+	clock.Advance(100 * time.Second)
+	v3, err := h.Activate(ctx, rebalancedConfig(t, func(c map[string]any) {
+		c["world"].(map[string]any)["barbarians"].(map[string]any)["frequency"] = 0.004
+	}))
+	mustNoErr(t, err)
+	if len(v3.villages) <= len(v1.villages) {
+		t.Fatalf("expected more villages, got %d after %d", len(v3.villages), len(v1.villages))
+	}
+	mustEqual(t, len(h.villagesByWorld), 2)
+}
+
+func TestConfigHistoryRejectsIncompatibleVersions(t *testing.T) {
+	ctx := context.Background()
+	h, err := NewConfigHistory(ctx, newInMemoryRepository(), NewFakeClock(time.Unix(1_000_000, 0)), "../config.json")
+	mustNoErr(t, err)
+
+	shrunk := rebalancedConfig(t, func(c map[string]any) {
+		mines := c["buildings"].(map[string]any)["mines"].(map[string]any)
+		mines["maxLevel"] = 5
+		for _, key := range []string{"cost", "upgradeSpeed"} {
+			mines[key] = mines[key].([]any)[:5]
+		}
+		mines["resourceMultiplier"] = mines["resourceMultiplier"].([]any)[:6]
+	})
+	// the version is valid on its own, only not as a successor
+	_, err = parseRawGameConfig(shrunk)
+	mustNoErr(t, err)
+	_, err = h.Activate(ctx, shrunk)
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("got %v, want %v", err, errInvalidConfig)
+	}
+	mustEqual(t, err.Error(), `invalid config:
+  - building mines: maxLevel cannot go down from 6 to 5`)
+
+	_, err = h.Activate(ctx, rebalancedConfig(t, func(c map[string]any) {
+		c["world"].(map[string]any)["seed"] = 96
+	}))
+	mustEqual(t, err.Error(), `invalid config:
+  - world: seed cannot change from 95 to 96, the terrain under the cities would`)
+	mustEqual(t, h.Latest().version, int64(1))
+
+	// raising a max level is fine, cities only ever go up to it
+	grown, err := h.Activate(ctx, rebalancedConfig(t, func(c map[string]any) {
+		mason := c["buildings"].(map[string]any)["mason"].(map[string]any)
+		mason["maxLevel"] = 4
+		for _, key := range []string{"cost", "upgradeSpeed", "resourceMultiplier"} {
+			levels := mason[key].([]any)
+			mason[key] = append(levels, levels[len(levels)-1])
+		}
+	}))
+	mustNoErr(t, err)
+	mustEqual(t, grown.version, int64(2))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(c *gameConfig)
+		problems []string
+	}{
+		{
+			name:   "default config",
+			mutate: func(c *gameConfig) {},
+		},
+		{
+			name: "research requires itself",
+			mutate: func(c *gameConfig) {
+				research := c.Research["masonry"]
+				research.RequiredResearch = []tResearchName{"masonry"}
+				c.Research["masonry"] = research
+			},
+			problems: []string{"research masonry: requires itself"},
+		},
+		{
+			name: "research requires itself through other research",
+			mutate: func(c *gameConfig) {
+				research := c.Research["swordsmanship"]
+				research.RequiredResearch = []tResearchName{"sharpsticks"}
+				c.Research["swordsmanship"] = research
+			},
+			problems: []string{"research sharpsticks: requires itself", "research swordsmanship: requires itself"},
+		},
+		{
+			name: "building requires itself",
+			mutate: func(c *gameConfig) {
+				building := c.Buildings["mines"]
+				building.RequiredBuildings = tBuildingsLevel{"mines": 1}
+				c.Buildings["mines"] = building
+			},
+			problems: []string{"building mines: requires itself"},
+		},
+		{
+			name: "building requires itself through other buildings",
+			mutate: func(c *gameConfig) {
+				building := c.Buildings["mines"]
+				building.RequiredBuildings = tBuildingsLevel{"mason": 1}
+				c.Buildings["mines"] = building
+			},
+			problems: []string{"building mason: requires itself", "building mines: requires itself"},
+		},
+		{
+			name: "research requires unknown research",
+			mutate: func(c *gameConfig) {
+				research := c.Research["masonry"]
+				research.RequiredResearch = []tResearchName{"alchemy"}
+				c.Research["masonry"] = research
+			},
+			problems: []string{"research masonry: requires invalid research alchemy"},
+		},
+		{
+			name: "negative upgradeSpeed",
+			mutate: func(c *gameConfig) {
+				building := c.Buildings["barracks"]
+				building.UpgradeSpeed = append([]tSec{-1}, building.UpgradeSpeed[1:]...)
+				c.Buildings["barracks"] = building
+			},
+			problems: []string{"building barracks level 1: upgradeSpeed must not be negative"},
+		},
+		{
+			name: "negative productionSpeed",
+			mutate: func(c *gameConfig) {
+				unit := c.Units["stickmen"]
+				unit.UnitProductionSpeedSec = -1
+				c.Units["stickmen"] = unit
+			},
+			problems: []string{"unit stickmen: productionSpeed must not be negative"},
+		},
+		{
+			name: "instant production",
+			mutate: func(c *gameConfig) {
+				unit := c.Units["stickmen"]
+				unit.UnitProductionSpeedSec = 0
+				c.Units["stickmen"] = unit
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseGameConfig("../config.json")
+			mustNoErr(t, err)
+			tt.mutate(&c)
+			err = c.Validate()
+			if len(tt.problems) == 0 {
+				mustNoErr(t, err)
+				return
+			}
+			if !errors.Is(err, errInvalidConfig) {
+				t.Fatalf("got %v, want %v", err, errInvalidConfig)
+			}
+			mustEqual(t, err.Error(), "invalid config:\n  - "+strings.Join(tt.problems, "\n  - "))
+		})
+	}
+}
+
+func TestCheckRequirements(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	tests := []struct {
+		name           string
+		check          func(buildingsLevel tBuildingsLevel) error
+		buildingsLevel tBuildingsLevel
+		wantErr        bool
+	}{
+		{
+			name:    "unit without a building to train it",
+			check:   func(b tBuildingsLevel) error { return cfg.checkUnitRequirements("stickmen", b) },
+			wantErr: true,
+		},
+		{
+			name:           "unit with a building to train it",
+			check:          func(b tBuildingsLevel) error { return cfg.checkUnitRequirements("stickmen", b) },
+			buildingsLevel: tBuildingsLevel{"barracks": 1},
+		},
+		{
+			name:           "unit below its required building level",
+			check:          func(b tBuildingsLevel) error { return cfg.checkUnitRequirements("swordsmen", b) },
+			buildingsLevel: tBuildingsLevel{"barracks": 1},
+			wantErr:        true,
+		},
+		{
+			name:           "unit at its required building level",
+			check:          func(b tBuildingsLevel) error { return cfg.checkUnitRequirements("swordsmen", b) },
+			buildingsLevel: tBuildingsLevel{"barracks": 2},
+		},
+		{
+			name:           "unit no building trains",
+			check:          func(b tBuildingsLevel) error { return cfg.checkUnitRequirements("god", b) },
+			buildingsLevel: tBuildingsLevel{"barracks": 5},
+			wantErr:        true,
+		},
+		{
+			name:           "building below its required building level",
+			check:          func(b tBuildingsLevel) error { return cfg.checkBuildingRequirements("mason", b) },
+			buildingsLevel: tBuildingsLevel{"mines": 2},
+			wantErr:        true,
+		},
+		{
+			name:           "building at its required building level",
+			check:          func(b tBuildingsLevel) error { return cfg.checkBuildingRequirements("mason", b) },
+			buildingsLevel: tBuildingsLevel{"mines": 3},
+		},
+		{
+			name:  "building without requirements",
+			check: func(b tBuildingsLevel) error { return cfg.checkBuildingRequirements("mines", b) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(tt.buildingsLevel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want an error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScaledDuration(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	tests := []struct {
+		worldSpeed float64
+		duration   tSec
+		want       tSec
+	}{
+		{worldSpeed: 1, duration: 120, want: 120},
+		{worldSpeed: 2, duration: 120, want: 60},
+		{worldSpeed: 0.5, duration: 120, want: 240},
+		// durations are whole seconds, rounded down
+		{worldSpeed: 3, duration: 100, want: 33},
+		{worldSpeed: 1000, duration: 120, want: 0},
+	}
+	for _, tt := range tests {
+		cfg.WorldSpeed = tt.worldSpeed
+		mustEqual(t, cfg.scaledDuration(tt.duration), tt.want)
+	}
+
+	// every duration goes through it, travels included
+	cfg.WorldSpeed = 1
+	travelTime := cfg.travelTime(0, 0, 30, 40, 1)
+	cfg.WorldSpeed = 2
+	mustEqual(t, cfg.travelTime(0, 0, 30, 40, 1), travelTime/2)
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"encoding/json"
+	"sort"
 
 	api "github.com/luisferreira32/stickerio/api"
 )
@@ -16,11 +17,20 @@ type (
 	tCityID              string
 	tUnitQueueItemID     string
 	tBuildingQueueItemID string
+	tAllianceID          string
+	tMailID              string
+	tResearchQueueItemID string
+	tTradeOfferID        string
+
+	tLeaderboardKind string
+	tMovementType    string
 
 	tBuildingName string
 	tUnitName     string
 	tUnitStatName string
 	tResourceName string
+	tResearchName string
+	tTerrainName  string
 
 	tBuildingLevel int64
 	tUnitCount     int64
@@ -50,6 +60,14 @@ func toUntypedMap[K ~string, V ~int64](m map[K]V) map[string]int64 {
 	return untypedMap
 }
 
+func toUntypedSlice[K ~string](s []K) []string {
+	untypedSlice := make([]string, len(s))
+	for i, k := range s {
+		untypedSlice[i] = string(k)
+	}
+	return untypedSlice
+}
+
 type dbCity struct {
 	id             tCityID
 	name           string
@@ -60,6 +78,8 @@ type dbCity struct {
 	resourceBase   string
 	resourceEpoch  tSec
 	unitCount      string
+	protectedUntil tSec
+	reinforcements string
 }
 
 type city struct {
@@ -72,33 +92,47 @@ type city struct {
 	resourceBase   tResourcesCount
 	resourceEpoch  tSec
 	unitCount      tUnitsCount
+	// hostile arrivals bounce back until then, see the beginner protection
+	protectedUntil tSec
+	// the units allies keep stationed here, they still belong to each ally
+	reinforcements map[tPlayerID]tUnitsCount
 }
 
 func cityToAPIModel(c *city) api.V1City {
+	var reinforcements map[string]map[string]int64
+	if len(c.reinforcements) > 0 {
+		reinforcements = make(map[string]map[string]int64, len(c.reinforcements))
+		for playerID, unitCount := range c.reinforcements {
+			reinforcements[string(playerID)] = toUntypedMap(unitCount)
+		}
+	}
 	return api.V1City{
 		CityInfo: api.V1CityInfo{
-			Id:        string(c.id),
-			Name:      string(c.name),
-			PlayerID:  string(c.playerID),
-			LocationX: int32(c.locationX),
-			LocationY: int32(c.locationY),
+			Id:             string(c.id),
+			Name:           string(c.name),
+			PlayerID:       string(c.playerID),
+			LocationX:      int32(c.locationX),
+			LocationY:      int32(c.locationY),
+			ProtectedUntil: api.PtrInt64(int64(c.protectedUntil)),
 		},
 		Buildings: toUntypedMap(c.buildingsLevel),
 		CityResources: api.V1CityResources{
 			Epoch:     int64(c.resourceEpoch),
 			BaseCount: toUntypedMap(c.resourceBase),
 		},
-		UnitCount: toUntypedMap(c.unitCount),
+		UnitCount:      toUntypedMap(c.unitCount),
+		Reinforcements: reinforcements,
 	}
 }
 
 func cityToCityInfoAPIModel(c *city) api.V1CityInfo {
 	return api.V1CityInfo{
-		Id:        string(c.id),
-		Name:      c.name,
-		PlayerID:  string(c.playerID),
-		LocationX: int32(c.locationX),
-		LocationY: int32(c.locationY),
+		Id:             string(c.id),
+		Name:           c.name,
+		PlayerID:       string(c.playerID),
+		LocationX:      int32(c.locationX),
+		LocationY:      int32(c.locationY),
+		ProtectedUntil: api.PtrInt64(int64(c.protectedUntil)),
 	}
 }
 
@@ -118,6 +152,13 @@ func cityFromDBModel(dbCity *dbCity) (*city, error) {
 	if err != nil {
 		return nil, err
 	}
+	reinforcements := make(map[tPlayerID]tUnitsCount)
+	if dbCity.reinforcements != "" {
+		err = json.Unmarshal([]byte(dbCity.reinforcements), &reinforcements)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &city{
 		id:             dbCity.id,
 		name:           dbCity.name,
@@ -128,9 +169,24 @@ func cityFromDBModel(dbCity *dbCity) (*city, error) {
 		resourceBase:   resourceBase,
 		resourceEpoch:  dbCity.resourceEpoch,
 		unitCount:      unitCount,
+		protectedUntil: dbCity.protectedUntil,
+		reinforcements: reinforcements,
 	}, nil
 }
 
+// cityInfoFromDBModel only reads the public city information, the city info
+// queries do not select the remaining columns.
+func cityInfoFromDBModel(dbCity *dbCity) *city {
+	return &city{
+		id:             dbCity.id,
+		name:           dbCity.name,
+		playerID:       dbCity.playerID,
+		locationX:      dbCity.locationX,
+		locationY:      dbCity.locationY,
+		protectedUntil: dbCity.protectedUntil,
+	}
+}
+
 func cityToDBModel(c *city) (*dbCity, error) {
 	resourceBase, err := json.Marshal(c.resourceBase)
 	if err != nil {
@@ -144,6 +200,13 @@ func cityToDBModel(c *city) (*dbCity, error) {
 	if err != nil {
 		return nil, err
 	}
+	reinforcements := []byte("{}")
+	if len(c.reinforcements) > 0 {
+		reinforcements, err = json.Marshal(c.reinforcements)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &dbCity{
 		id:             c.id,
 		name:           c.name,
@@ -154,6 +217,8 @@ func cityToDBModel(c *city) (*dbCity, error) {
 		resourceBase:   string(resourceBase),
 		resourceEpoch:  c.resourceEpoch,
 		unitCount:      string(unitCount),
+		protectedUntil: c.protectedUntil,
+		reinforcements: string(reinforcements),
 	}, nil
 }
 
@@ -168,6 +233,7 @@ type dbMovement struct {
 	speed          tSpeed
 	resourceCount  string
 	unitCount      string
+	movementType   tMovementType
 }
 
 type movement struct {
@@ -181,8 +247,16 @@ type movement struct {
 	speed          tSpeed
 	resourceCount  tResourcesCount
 	unitCount      tUnitsCount
+	movementType   tMovementType
 }
 
+// Troops fight, forage and settle wherever they arrive, transports only
+// deliver their resources to the city there.
+const (
+	troopsMovement    tMovementType = "troops"
+	transportMovement tMovementType = "transport"
+)
+
 func movementToAPIModel(m *movement) api.V1Movement {
 	resources := make(map[string]int64, len(m.resourceCount))
 	for k, v := range m.resourceCount {
@@ -203,6 +277,7 @@ func movementToAPIModel(m *movement) api.V1Movement {
 		Speed:          float64(m.speed),
 		UnitCount:      units,
 		ResourceCount:  resources,
+		Type:           api.PtrString(string(m.movementType)),
 	}
 }
 
@@ -228,6 +303,7 @@ func movementFromDBModel(dbMovement *dbMovement) (*movement, error) {
 		speed:          dbMovement.speed,
 		resourceCount:  resourceCount,
 		unitCount:      unitCount,
+		movementType:   dbMovement.movementType,
 	}, nil
 }
 
@@ -251,6 +327,7 @@ func movementToDBModel(m *movement) (*dbMovement, error) {
 		speed:          m.speed,
 		resourceCount:  string(resourceCount),
 		unitCount:      string(unitCount),
+		movementType:   m.movementType,
 	}, nil
 }
 
@@ -362,18 +439,481 @@ func buildingQueueItemToDBModel(item *buildingQueueItem) *dbBuildingQueueItem {
 	}
 }
 
+type dbAlliance struct {
+	id       tAllianceID
+	name     string
+	leaderID tPlayerID
+	invites  string
+	members  []tPlayerID
+}
+
+type alliance struct {
+	id       tAllianceID
+	name     string
+	leaderID tPlayerID
+	invites  map[tPlayerID]struct{}
+	members  map[tPlayerID]struct{}
+}
+
+func allianceToAPIModel(a *alliance) api.V1Alliance {
+	members := make([]string, 0, len(a.members))
+	for playerID := range a.members {
+		members = append(members, string(playerID))
+	}
+	sort.Strings(members)
+	invites := make([]string, 0, len(a.invites))
+	for playerID := range a.invites {
+		invites = append(invites, string(playerID))
+	}
+	sort.Strings(invites)
+	return api.V1Alliance{
+		AllianceInfo: allianceToAllianceInfoAPIModel(a),
+		Members:      members,
+		Invites:      invites,
+	}
+}
+
+func allianceToAllianceInfoAPIModel(a *alliance) api.V1AllianceInfo {
+	return api.V1AllianceInfo{
+		Id:       string(a.id),
+		Name:     a.name,
+		LeaderID: string(a.leaderID),
+	}
+}
+
+func allianceFromDBModel(dbAlliance *dbAlliance) (*alliance, error) {
+	invitesList := make([]tPlayerID, 0)
+	if dbAlliance.invites != "" {
+		err := json.Unmarshal([]byte(dbAlliance.invites), &invitesList)
+		if err != nil {
+			return nil, err
+		}
+	}
+	invites := make(map[tPlayerID]struct{}, len(invitesList))
+	for _, playerID := range invitesList {
+		invites[playerID] = struct{}{}
+	}
+	members := make(map[tPlayerID]struct{}, len(dbAlliance.members))
+	for _, playerID := range dbAlliance.members {
+		members[playerID] = struct{}{}
+	}
+	return &alliance{
+		id:       dbAlliance.id,
+		name:     dbAlliance.name,
+		leaderID: dbAlliance.leaderID,
+		invites:  invites,
+		members:  members,
+	}, nil
+}
+
+func allianceToDBModel(a *alliance) (*dbAlliance, error) {
+	invitesList := make([]tPlayerID, 0, len(a.invites))
+	for playerID := range a.invites {
+		invitesList = append(invitesList, playerID)
+	}
+	sort.Slice(invitesList, func(i, j int) bool { return invitesList[i] < invitesList[j] })
+	invites, err := json.Marshal(invitesList)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]tPlayerID, 0, len(a.members))
+	for playerID := range a.members {
+		members = append(members, playerID)
+	}
+	return &dbAlliance{
+		id:       a.id,
+		name:     a.name,
+		leaderID: a.leaderID,
+		invites:  string(invites),
+		members:  members,
+	}, nil
+}
+
+type dbPlayer struct {
+	id            tPlayerID
+	allianceID    tAllianceID
+	score         int64
+	attackPoints  int64
+	defencePoints int64
+	research      string
+}
+
+func playerResearchToDBModel(research map[tResearchName]struct{}) (string, error) {
+	researchList := make([]tResearchName, 0, len(research))
+	for researchName := range research {
+		researchList = append(researchList, researchName)
+	}
+	sort.Slice(researchList, func(i, j int) bool { return researchList[i] < researchList[j] })
+	b, err := json.Marshal(researchList)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func playerResearchFromDBModel(research string) ([]tResearchName, error) {
+	researchList := make([]tResearchName, 0)
+	if research == "" {
+		return researchList, nil
+	}
+	err := json.Unmarshal([]byte(research), &researchList)
+	if err != nil {
+		return nil, err
+	}
+	return researchList, nil
+}
+
+type dbResearchQueueItem struct {
+	id           tResearchQueueItemID
+	cityID       tCityID
+	playerID     tPlayerID
+	queuedEpoch  tSec
+	durationSec  tSec
+	researchName tResearchName
+}
+
+type researchQueueItem struct {
+	id           tResearchQueueItemID
+	cityID       tCityID
+	playerID     tPlayerID
+	queuedEpoch  tSec
+	durationSec  tSec
+	researchName tResearchName
+}
+
+func researchQueueItemToAPIModel(item *researchQueueItem) api.V1ResearchQueueItem {
+	return api.V1ResearchQueueItem{
+		Id:          string(item.id),
+		QueuedEpoch: int64(item.queuedEpoch),
+		DurationSec: int64(item.durationSec),
+		Research:    string(item.researchName),
+	}
+}
+
+func researchQueueItemFromDBModel(dbItem *dbResearchQueueItem) *researchQueueItem {
+	return &researchQueueItem{
+		id:           dbItem.id,
+		cityID:       dbItem.cityID,
+		playerID:     dbItem.playerID,
+		queuedEpoch:  dbItem.queuedEpoch,
+		durationSec:  dbItem.durationSec,
+		researchName: dbItem.researchName,
+	}
+}
+
+func researchQueueItemToDBModel(item *researchQueueItem) *dbResearchQueueItem {
+	return &dbResearchQueueItem{
+		id:           item.id,
+		cityID:       item.cityID,
+		playerID:     item.playerID,
+		queuedEpoch:  item.queuedEpoch,
+		durationSec:  item.durationSec,
+		researchName: item.researchName,
+	}
+}
+
+type dbTradeOffer struct {
+	id           tTradeOfferID
+	playerID     tPlayerID
+	cityID       tCityID
+	offered      string
+	requested    string
+	createdEpoch tSec
+}
+
+// A trade offer holds the offered resources of its city until another player
+// accepts it, or until it is cancelled.
+type tradeOffer struct {
+	id           tTradeOfferID
+	playerID     tPlayerID
+	cityID       tCityID
+	offered      tResourcesCount
+	requested    tResourcesCount
+	createdEpoch tSec
+}
+
+func tradeOfferToAPIModel(o *tradeOffer) api.V1TradeOffer {
+	return api.V1TradeOffer{
+		Id:           string(o.id),
+		PlayerID:     api.PtrString(string(o.playerID)),
+		CityID:       string(o.cityID),
+		Offered:      toUntypedMap(o.offered),
+		Requested:    toUntypedMap(o.requested),
+		CreatedEpoch: api.PtrInt64(int64(o.createdEpoch)),
+	}
+}
+
+func tradeOfferFromDBModel(dbOffer *dbTradeOffer) (*tradeOffer, error) {
+	offered := make(tResourcesCount)
+	err := json.Unmarshal([]byte(dbOffer.offered), &offered)
+	if err != nil {
+		return nil, err
+	}
+	requested := make(tResourcesCount)
+	err = json.Unmarshal([]byte(dbOffer.requested), &requested)
+	if err != nil {
+		return nil, err
+	}
+	return &tradeOffer{
+		id:           dbOffer.id,
+		playerID:     dbOffer.playerID,
+		cityID:       dbOffer.cityID,
+		offered:      offered,
+		requested:    requested,
+		createdEpoch: dbOffer.createdEpoch,
+	}, nil
+}
+
+func tradeOfferToDBModel(o *tradeOffer) (*dbTradeOffer, error) {
+	offered, err := json.Marshal(o.offered)
+	if err != nil {
+		return nil, err
+	}
+	requested, err := json.Marshal(o.requested)
+	if err != nil {
+		return nil, err
+	}
+	return &dbTradeOffer{
+		id:           o.id,
+		playerID:     o.playerID,
+		cityID:       o.cityID,
+		offered:      string(offered),
+		requested:    string(requested),
+		createdEpoch: o.createdEpoch,
+	}, nil
+}
+
+const (
+	overallLeaderboard  tLeaderboardKind = "overall"
+	attackLeaderboard   tLeaderboardKind = "attack"
+	defenceLeaderboard  tLeaderboardKind = "defence"
+	allianceLeaderboard tLeaderboardKind = "alliance"
+)
+
+// A map tile is a location of the world, along with the city on it if any.
+type mapTile struct {
+	x              tCoordinate
+	y              tCoordinate
+	terrain        tTerrainName
+	resourceFields []tResourceName
+	cityID         tCityID
+	playerID       tPlayerID
+	allianceID     tAllianceID
+	size           int64
+}
+
+// A map region counts the cities in a square of the map, the zoomed out map.
+type mapRegion struct {
+	x      tCoordinate
+	y      tCoordinate
+	width  tCoordinate
+	cities int64
+}
+
+// The size of a city on the map grows with its total building levels.
+var citySizeThresholds = []tBuildingLevel{5, 15, 30}
+
+func mapTileFromCity(c *city, allianceID tAllianceID) *mapTile {
+	var levels tBuildingLevel
+	for _, level := range c.buildingsLevel {
+		levels += level
+	}
+	var size int64
+	for _, threshold := range citySizeThresholds {
+		if levels >= threshold {
+			size++
+		}
+	}
+	return &mapTile{
+		x:          c.locationX,
+		y:          c.locationY,
+		cityID:     c.id,
+		playerID:   c.playerID,
+		allianceID: allianceID,
+		size:       size,
+	}
+}
+
+func mapToAPIModel(tiles []*mapTile, regions []*mapRegion) api.V1Map {
+	resp := api.V1Map{
+		Tiles:   make([]api.V1MapTile, len(tiles)),
+		Regions: make([]api.V1MapRegion, len(regions)),
+	}
+	for i, t := range tiles {
+		resp.Tiles[i] = api.V1MapTile{
+			X:              int32(t.x),
+			Y:              int32(t.y),
+			Terrain:        string(t.terrain),
+			ResourceFields: toUntypedSlice(t.resourceFields),
+			CityID:         string(t.cityID),
+			PlayerID:       string(t.playerID),
+			AllianceID:     string(t.allianceID),
+			Size:           int32(t.size),
+		}
+	}
+	for i, r := range regions {
+		resp.Regions[i] = api.V1MapRegion{
+			X:      int32(r.x),
+			Y:      int32(r.y),
+			Width:  int32(r.width),
+			Cities: int32(r.cities),
+		}
+	}
+	return resp
+}
+
+type dbLeaderboardEntry struct {
+	id    string
+	rank  int64
+	score int64
+}
+
+type leaderboardEntry struct {
+	id    string
+	rank  int64
+	score int64
+}
+
+func leaderboardEntryToAPIModel(l *leaderboardEntry) api.V1LeaderboardEntry {
+	return api.V1LeaderboardEntry{
+		Id:    l.id,
+		Rank:  l.rank,
+		Score: l.score,
+	}
+}
+
+func leaderboardEntryFromDBModel(dbLeaderboardEntry *dbLeaderboardEntry) *leaderboardEntry {
+	return &leaderboardEntry{
+		id:    dbLeaderboardEntry.id,
+		rank:  dbLeaderboardEntry.rank,
+		score: dbLeaderboardEntry.score,
+	}
+}
+
+// dbViews are the view changes of a processed event or of a whole re-sync,
+// along with the chain events it created, they are written all or nothing.
+type dbViews struct {
+	events                    []*event // inserted unless they already are
+	cities                    []*dbCity
+	deletedCities             []tCityID // along with their queues
+	movements                 []*dbMovement
+	deletedMovements          []tMovementID
+	unitQueueItems            []*dbUnitQueueItem
+	deletedUnitQueueItems     []tUnitQueueItemID
+	buildingQueueItems        []*dbBuildingQueueItem
+	deletedBuildingQueueItems []tBuildingQueueItemID
+	researchQueueItems        []*dbResearchQueueItem
+	deletedResearchQueueItems []tResearchQueueItemID
+	alliances                 []*dbAlliance
+	deletedAlliances          []tAllianceID
+	players                   []*dbPlayer
+	tradeOffers               []*dbTradeOffer
+	deletedTradeOffers        []tTradeOfferID
+	mails                     []*dbMailDelivery // delivered unless they already are
+}
+
+// dbMailDelivery is a mail along with the players it is delivered to.
+type dbMailDelivery struct {
+	mail       *dbMail
+	recipients []tPlayerID
+}
+
+type dbConfigVersion struct {
+	version       int64
+	effectiveFrom int64
+	sourceHash    string
+	config        string
+}
+
+type dbMail struct {
+	id          tMailID
+	senderID    tPlayerID
+	recipientID tPlayerID
+	allianceID  tAllianceID
+	subject     string
+	content     string
+	sentEpoch   tSec
+	read        bool
+}
+
+type mail struct {
+	id          tMailID
+	senderID    tPlayerID
+	recipientID tPlayerID
+	allianceID  tAllianceID
+	subject     string
+	content     string
+	sentEpoch   tSec
+	read        bool
+}
+
+func mailToAPIModel(m *mail) api.V1Mail {
+	return api.V1Mail{
+		Id:          string(m.id),
+		SenderID:    string(m.senderID),
+		RecipientID: string(m.recipientID),
+		AllianceID:  string(m.allianceID),
+		Subject:     m.subject,
+		Content:     m.content,
+		SentEpoch:   int64(m.sentEpoch),
+		Read:        m.read,
+	}
+}
+
+func mailFromDBModel(dbMail *dbMail) *mail {
+	return &mail{
+		id:          dbMail.id,
+		senderID:    dbMail.senderID,
+		recipientID: dbMail.recipientID,
+		allianceID:  dbMail.allianceID,
+		subject:     dbMail.subject,
+		content:     dbMail.content,
+		sentEpoch:   dbMail.sentEpoch,
+		read:        dbMail.read,
+	}
+}
+
+func mailToDBModel(m *mail) *dbMail {
+	return &dbMail{
+		id:          m.id,
+		senderID:    m.senderID,
+		recipientID: m.recipientID,
+		allianceID:  m.allianceID,
+		subject:     m.subject,
+		content:     m.content,
+		sentEpoch:   m.sentEpoch,
+		read:        m.read,
+	}
+}
+
 const (
-	startMovementEventName   tEventName = "startmovement"
-	arrivalMovementEventName tEventName = "arrival"
-	returnMovementEventName  tEventName = "returnmovement"
-	queueUnitEventName       tEventName = "queueunit"
-	createUnitEventName      tEventName = "createunit"
-	queueBuildingEventName   tEventName = "queuebuilding"
-	upgradeBuildingEventName tEventName = "upgradebuilding"
-	createCityEventName      tEventName = "createcity"
-	deleteCityEventName      tEventName = "deletecity"
+	startMovementEventName        tEventName = "startmovement"
+	arrivalMovementEventName      tEventName = "arrival"
+	returnMovementEventName       tEventName = "returnmovement"
+	queueUnitEventName            tEventName = "queueunit"
+	createUnitEventName           tEventName = "createunit"
+	queueBuildingEventName        tEventName = "queuebuilding"
+	upgradeBuildingEventName      tEventName = "upgradebuilding"
+	createCityEventName           tEventName = "createcity"
+	deleteCityEventName           tEventName = "deletecity"
+	createAllianceEventName       tEventName = "createalliance"
+	inviteToAllianceEventName     tEventName = "invitetoalliance"
+	joinAllianceEventName         tEventName = "joinalliance"
+	leaveAllianceEventName        tEventName = "leavealliance"
+	kickFromAllianceEventName     tEventName = "kickfromalliance"
+	queueResearchEventName        tEventName = "queueresearch"
+	completeResearchEventName     tEventName = "completeresearch"
+	regrowVillageEventName        tEventName = "regrowvillage"
+	createTradeOfferEventName     tEventName = "createtradeoffer"
+	acceptTradeOfferEventName     tEventName = "accepttradeoffer"
+	cancelTradeOfferEventName     tEventName = "canceltradeoffer"
+	exchangeResourcesEventName    tEventName = "exchangeresources"
+	recallReinforcementsEventName tEventName = "recallreinforcements"
 )
 
+// Barbarian villages belong to no player, they are all owned by this one.
+const barbarianPlayerID tPlayerID = "barbarians"
+
 type event struct {
 	id      tEventID
 	name    tEventName
@@ -391,6 +931,8 @@ type startMovementEvent struct {
 	DepartureEpoch tSec            `json:"departureEpoch"`
 	UnitCount      tUnitsCount     `json:"unitCount"`
 	ResourceCount  tResourcesCount `json:"resourceCount"`
+	// omitted by the movements that predate transports, they are all troops
+	MovementType tMovementType `json:"movementType,omitempty"`
 }
 
 type arrivalMovementEvent struct {
@@ -402,6 +944,7 @@ type arrivalMovementEvent struct {
 	DestinationY  tCoordinate     `json:"destinationY"`
 	UnitCount     tUnitsCount     `json:"unitCount"`
 	ResourceCount tResourcesCount `json:"resourceCount"`
+	MovementType  tMovementType   `json:"movementType,omitempty"`
 }
 
 type returnMovementEvent struct {
@@ -461,3 +1004,81 @@ type deleteCityEvent struct {
 	CityID   tCityID   `json:"cityID"`
 	PlayerID tPlayerID `json:"playerID"`
 }
+
+type createAllianceEvent struct {
+	AllianceID tAllianceID `json:"allianceID"`
+	Name       string      `json:"name"`
+	PlayerID   tPlayerID   `json:"playerID"`
+}
+
+type inviteToAllianceEvent struct {
+	AllianceID tAllianceID `json:"allianceID"`
+	PlayerID   tPlayerID   `json:"playerID"`
+	InviteeID  tPlayerID   `json:"inviteeID"`
+}
+
+type joinAllianceEvent struct {
+	AllianceID tAllianceID `json:"allianceID"`
+	PlayerID   tPlayerID   `json:"playerID"`
+}
+
+type leaveAllianceEvent struct {
+	AllianceID tAllianceID `json:"allianceID"`
+	PlayerID   tPlayerID   `json:"playerID"`
+}
+
+type kickFromAllianceEvent struct {
+	AllianceID tAllianceID `json:"allianceID"`
+	PlayerID   tPlayerID   `json:"playerID"`
+	MemberID   tPlayerID   `json:"memberID"`
+}
+
+type queueResearchEvent struct {
+	ResearchQueueItemID tResearchQueueItemID `json:"researchQueueItemID"`
+	CityID              tCityID              `json:"cityID"`
+	PlayerID            tPlayerID            `json:"playerID"`
+	ResearchName        tResearchName        `json:"researchName"`
+}
+
+type completeResearchEvent struct {
+	ResearchQueueItemID tResearchQueueItemID `json:"researchQueueItemID"`
+	CityID              tCityID              `json:"cityID"`
+	PlayerID            tPlayerID            `json:"playerID"`
+	ResearchName        tResearchName        `json:"researchName"`
+}
+
+type regrowVillageEvent struct {
+	CityID tCityID `json:"cityID"`
+}
+
+type createTradeOfferEvent struct {
+	TradeOfferID tTradeOfferID   `json:"tradeOfferID"`
+	PlayerID     tPlayerID       `json:"playerID"`
+	CityID       tCityID         `json:"cityID"`
+	Offered      tResourcesCount `json:"offered"`
+	Requested    tResourcesCount `json:"requested"`
+}
+
+type acceptTradeOfferEvent struct {
+	TradeOfferID tTradeOfferID `json:"tradeOfferID"`
+	PlayerID     tPlayerID     `json:"playerID"`
+	CityID       tCityID       `json:"cityID"`
+}
+
+type cancelTradeOfferEvent struct {
+	TradeOfferID tTradeOfferID `json:"tradeOfferID"`
+	PlayerID     tPlayerID     `json:"playerID"`
+}
+
+type exchangeResourcesEvent struct {
+	PlayerID tPlayerID      `json:"playerID"`
+	CityID   tCityID        `json:"cityID"`
+	From     tResourceName  `json:"from"`
+	To       tResourceName  `json:"to"`
+	Count    tResourceCount `json:"count"`
+}
+
+type recallReinforcementsEvent struct {
+	PlayerID tPlayerID `json:"playerID"`
+	CityID   tCityID   `json:"cityID"`
+}
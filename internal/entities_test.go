@@ -1 +1,34 @@
 package internal
+
+import "testing"
+
+func TestCityDBModel(t *testing.T) {
+	c := &city{
+		id:             "c1",
+		name:           "one",
+		playerID:       "p1",
+		locationX:      -3,
+		locationY:      4,
+		buildingsLevel: tBuildingsLevel{"mines": 2},
+		resourceBase:   tResourcesCount{"sticks": 10},
+		resourceEpoch:  5,
+		unitCount:      tUnitsCount{"stickmen": 3},
+		protectedUntil: 7,
+		reinforcements: map[tPlayerID]tUnitsCount{"p2": {"swordsmen": 1}},
+	}
+	dbc, err := cityToDBModel(c)
+	mustNoErr(t, err)
+	got, err := cityFromDBModel(dbc)
+	mustNoErr(t, err)
+	mustEqual(t, got, c)
+
+	// cities stored before there were reinforcements have none
+	dbc.reinforcements = ""
+	got, err = cityFromDBModel(dbc)
+	mustNoErr(t, err)
+	mustEqual(t, got.reinforcements, map[tPlayerID]tUnitsCount{})
+	c.reinforcements = nil
+	dbc, err = cityToDBModel(c)
+	mustNoErr(t, err)
+	mustEqual(t, dbc.reinforcements, "{}")
+}
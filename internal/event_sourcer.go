@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"math"
 	"math/rand"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +38,13 @@ type eventsRepository interface {
 	DeleteUnitQueueItemsFromCity(ctx context.Context, cityID string) error
 	DeleteBuildingQueueItem(ctx context.Context, id string) error
 	DeleteBuildingQueueItemsFromCity(ctx context.Context, cityID string) error
+	UpsertResearchQueueItem(ctx context.Context, m *dbResearchQueueItem) error
+	DeleteResearchQueueItem(ctx context.Context, id string) error
+	DeleteResearchQueueItemsFromCity(ctx context.Context, cityID string) error
+	UpsertAlliance(ctx context.Context, a *dbAlliance) error
+	DeleteAlliance(ctx context.Context, id string) error
+	UpsertPlayer(ctx context.Context, p *dbPlayer) error
+	UpsertViews(ctx context.Context, v *dbViews) error
 }
 
 type upsertIDs struct {
@@ -41,6 +52,31 @@ type upsertIDs struct {
 	movements map[tMovementID]struct{}
 	unitQ     map[tCityID]map[tUnitQueueItemID]struct{}
 	buildingQ map[tCityID]map[tBuildingQueueItemID]struct{}
+	researchQ map[tCityID]map[tResearchQueueItemID]struct{}
+	alliances map[tAllianceID]struct{}
+	players   map[tPlayerID]struct{}
+	offers    map[tTradeOfferID]struct{}
+}
+
+func (u upsertIDs) addUnitQueueItem(cityID tCityID, itemID tUnitQueueItemID) {
+	if _, ok := u.unitQ[cityID]; !ok {
+		u.unitQ[cityID] = make(map[tUnitQueueItemID]struct{})
+	}
+	u.unitQ[cityID][itemID] = struct{}{}
+}
+
+func (u upsertIDs) addBuildingQueueItem(cityID tCityID, itemID tBuildingQueueItemID) {
+	if _, ok := u.buildingQ[cityID]; !ok {
+		u.buildingQ[cityID] = make(map[tBuildingQueueItemID]struct{})
+	}
+	u.buildingQ[cityID][itemID] = struct{}{}
+}
+
+func (u upsertIDs) addResearchQueueItem(cityID tCityID, itemID tResearchQueueItemID) {
+	if _, ok := u.researchQ[cityID]; !ok {
+		u.researchQ[cityID] = make(map[tResearchQueueItemID]struct{})
+	}
+	u.researchQ[cityID][itemID] = struct{}{}
 }
 
 // The EventSourcer is the magic of this game.
@@ -52,6 +88,9 @@ type upsertIDs struct {
 //   - a map of movement IDs to full movement descriptions
 //   - a map of city IDs to a map of unit queue itmes
 //   - a map of city IDs to a map of building queue itmes
+//   - a map of city IDs to a map of research queue itmes and of player IDs to their research
+//   - a map of alliance IDs to alliances and of player IDs to their alliance
+//   - a map of trade offer IDs to the open trade offers
 //
 // Any of the event processors will do:
 //   - event payload parsing
@@ -63,29 +102,52 @@ type upsertIDs struct {
 // Chain events are only processed on re-sync: make it so that re-sync happens often enough.
 type EventSourcer struct {
 	repository eventsRepository
+	configs    *ConfigHistory
+	clock      Clock
+	// The config version in effect at the epoch of the event being processed.
+	cfg *Config
 
 	inMemoryStateLock *sync.Mutex
 	inMemoryState     *inMemoryStorage
 	// Goes through a phase of population and deletion while inMemoryStateLock
 	// is locked. Utilized to seletively upsert changes to the views.
 	toUpsert upsertIDs
+	// Chain events created while processing, inserted in the same transaction
+	// as the views they come along with.
+	chainEvents []*event
+	// System mail sent while processing, delivered along with the views too.
+	mails []*dbMailDelivery
+	// A re-sync replays the events that sent mail already, e.g., a rejected
+	// command, it is only delivered the first time.
+	deliveredMails map[tMailID]struct{}
+	// Villages are spawned again on every re-sync, their views only need to be
+	// written the first time.
+	spawnedVillageViews map[tCityID]struct{}
 
 	internalEventQueue chan *event
 }
 
-func NewEventSourcer(repository eventsRepository) *EventSourcer {
+func NewEventSourcer(repository eventsRepository, configs *ConfigHistory, clock Clock) *EventSourcer {
 	inMemoryState := &inMemoryStorage{}
 	inMemoryState.clear()
 	return &EventSourcer{
-		repository:         repository,
-		inMemoryStateLock:  &sync.Mutex{},
-		internalEventQueue: make(chan *event, 100),
-		inMemoryState:      inMemoryState,
+		repository:          repository,
+		configs:             configs,
+		clock:               clock,
+		inMemoryStateLock:   &sync.Mutex{},
+		internalEventQueue:  make(chan *event, 100),
+		inMemoryState:       inMemoryState,
+		spawnedVillageViews: make(map[tCityID]struct{}),
+		deliveredMails:      make(map[tMailID]struct{}),
 		toUpsert: upsertIDs{
 			cities:    make(map[tCityID]struct{}),
 			movements: make(map[tMovementID]struct{}),
 			unitQ:     make(map[tCityID]map[tUnitQueueItemID]struct{}),
 			buildingQ: make(map[tCityID]map[tBuildingQueueItemID]struct{}),
+			researchQ: make(map[tCityID]map[tResearchQueueItemID]struct{}),
+			alliances: make(map[tAllianceID]struct{}),
+			players:   make(map[tPlayerID]struct{}),
+			offers:    make(map[tTradeOfferID]struct{}),
 		},
 	}
 }
@@ -116,24 +178,96 @@ func (s *EventSourcer) StartEventsWorker(ctx context.Context, resyncPeriod time.
 		case <-resyncTimer.C:
 			err := s.reSyncEvents(ctx)
 			if err != nil {
-				log.Printf("Re-sync events on %v, got: %v", time.Now(), err)
+				log.Printf("Re-sync events on %v, got: %v", s.clock.Now(), err)
 			}
 		default:
 		}
 	}
 }
 
+// Sync re-syncs right away instead of waiting for the worker, processing the
+// queued events along with every chain event due. It allows to step through a
+// world deterministically, e.g., in end to end tests.
+func (s *EventSourcer) Sync(ctx context.Context) error {
+	for {
+		select {
+		case <-s.internalEventQueue:
+		default:
+			return s.reSyncEvents(ctx)
+		}
+	}
+}
+
+// mapTiles are the tiles within the bounds with anything on them but the
+// default terrain: a city, another terrain or resource fields. They are ordered
+// row by row.
+func (s *EventSourcer) mapTiles(x1, y1, x2, y2 tCoordinate) []*mapTile {
+	s.inMemoryStateLock.Lock()
+	defer s.inMemoryStateLock.Unlock()
+
+	cfg := s.configs.Latest()
+	cities := s.inMemoryState.citiesWithin(x1, y1, x2, y2)
+	tiles := make([]*mapTile, 0, len(cities))
+	// NOTE: the cities are in the same order the tiles are gone through
+	nextCity := 0
+	for y := int64(y1); y <= int64(y2); y++ {
+		for x := int64(x1); x <= int64(x2); x++ {
+			worldTile := cfg.tileAt(tCoordinate(x), tCoordinate(y))
+			tile := &mapTile{x: tCoordinate(x), y: tCoordinate(y)}
+			if nextCity < len(cities) && cities[nextCity].locationX == tile.x && cities[nextCity].locationY == tile.y {
+				c := cities[nextCity]
+				tile = mapTileFromCity(c, s.inMemoryState.allianceByPlayer[c.playerID])
+				nextCity++
+			} else if worldTile.terrain == cfg.World.DefaultTerrain && len(worldTile.resourceFields) == 0 {
+				continue
+			}
+			tile.terrain = worldTile.terrain
+			tile.resourceFields = worldTile.resourceFields
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles
+}
+
+// mapRegions count the cities within the bounds per region, leaving out the
+// regions without any.
+func (s *EventSourcer) mapRegions(x1, y1, x2, y2 tCoordinate) []*mapRegion {
+	s.inMemoryStateLock.Lock()
+	defer s.inMemoryStateLock.Unlock()
+
+	from, to := regionOf(x1, y1), regionOf(x2, y2)
+	regions := make([]*mapRegion, 0)
+	for ry := from.y; ry <= to.y; ry++ {
+		for rx := from.x; rx <= to.x; rx++ {
+			var count int64
+			for _, c := range s.inMemoryState.cityByRegion[coordinates{x: rx, y: ry}] {
+				if c.locationX >= x1 && c.locationX <= x2 && c.locationY >= y1 && c.locationY <= y2 {
+					count++
+				}
+			}
+			if count == 0 {
+				continue
+			}
+			regions = append(regions, &mapRegion{x: rx * regionSize, y: ry * regionSize, width: regionSize, cities: count})
+		}
+	}
+	return regions
+}
+
 func (s *EventSourcer) processEvent(ctx context.Context, e *event) error {
 	s.inMemoryStateLock.Lock()
 	defer s.inMemoryStateLock.Unlock()
 
-	if e.epoch > tSec(time.Now().Unix()) {
+	if e.epoch > tSec(s.clock.Now().Unix()) {
 		return fmt.Errorf("future event cannot be processed")
 	}
 
 	var (
 		err error
 	)
+	pendingChainEvents, pendingMails := len(s.chainEvents), len(s.mails)
+	s.spawnVillages(e.epoch)
+	s.cfg = s.configs.At(e.epoch)
 	switch e.name {
 	case startMovementEventName:
 		err = s.processStartMovementEvent(ctx, e)
@@ -153,19 +287,53 @@ func (s *EventSourcer) processEvent(ctx context.Context, e *event) error {
 		err = s.processCreateCityEvent(ctx, e)
 	case deleteCityEventName:
 		err = s.processDeleteCityEvent(ctx, e)
+	case createAllianceEventName:
+		err = s.processCreateAllianceEvent(ctx, e)
+	case inviteToAllianceEventName:
+		err = s.processInviteToAllianceEvent(ctx, e)
+	case joinAllianceEventName:
+		err = s.processJoinAllianceEvent(ctx, e)
+	case leaveAllianceEventName:
+		err = s.processLeaveAllianceEvent(ctx, e)
+	case kickFromAllianceEventName:
+		err = s.processKickFromAllianceEvent(ctx, e)
+	case queueResearchEventName:
+		err = s.processQueueResearchEvent(ctx, e)
+	case completeResearchEventName:
+		err = s.processCompleteResearchEvent(ctx, e)
+	case regrowVillageEventName:
+		err = s.processRegrowVillageEvent(ctx, e)
+	case createTradeOfferEventName:
+		err = s.processCreateTradeOfferEvent(ctx, e)
+	case acceptTradeOfferEventName:
+		err = s.processAcceptTradeOfferEvent(ctx, e)
+	case cancelTradeOfferEventName:
+		err = s.processCancelTradeOfferEvent(ctx, e)
+	case exchangeResourcesEventName:
+		err = s.processExchangeResourcesEvent(ctx, e)
+	case recallReinforcementsEventName:
+		err = s.processRecallReinforcementsEvent(ctx, e)
 	}
 	if err != nil {
+		s.chainEvents = s.chainEvents[:pendingChainEvents]
+		s.mails = s.mails[:pendingMails]
+		if !errors.Is(err, errPreConditionFailed) {
+			return err
+		}
+		// the report is delivered right away, along with anything still pending
+		s.reportRejectedEvent(e, err)
+		if upsertErr := s.upsertViews(ctx); upsertErr != nil {
+			return upsertErr
+		}
 		return err
 	}
 
 	// upsert view tables to upsert and clear the maps
-	s.upsertViews(ctx)
-
-	return nil
+	return s.upsertViews(ctx)
 }
 
 func (s *EventSourcer) reSyncEvents(ctx context.Context) error {
-	events, err := s.repository.ListEvents(ctx, time.Now().Unix())
+	events, err := s.repository.ListEvents(ctx, s.clock.Now().Unix())
 	if err != nil {
 		return err
 	}
@@ -179,8 +347,13 @@ func (s *EventSourcer) reSyncEvents(ctx context.Context) error {
 	var (
 		e *event
 	)
+	// the views are written with the config of the last event replayed, if any
+	s.cfg = s.configs.At(tSec(s.clock.Now().Unix()))
 	for i := 0; i < len(events); i++ {
 		e = events[i]
+		pendingChainEvents, pendingMails := len(s.chainEvents), len(s.mails)
+		s.spawnVillages(e.epoch)
+		s.cfg = s.configs.At(e.epoch)
 		switch e.name {
 		case startMovementEventName:
 			err = s.processStartMovementEvent(ctx, e)
@@ -200,107 +373,230 @@ func (s *EventSourcer) reSyncEvents(ctx context.Context) error {
 			err = s.processCreateCityEvent(ctx, e)
 		case deleteCityEventName:
 			err = s.processDeleteCityEvent(ctx, e)
+		case createAllianceEventName:
+			err = s.processCreateAllianceEvent(ctx, e)
+		case inviteToAllianceEventName:
+			err = s.processInviteToAllianceEvent(ctx, e)
+		case joinAllianceEventName:
+			err = s.processJoinAllianceEvent(ctx, e)
+		case leaveAllianceEventName:
+			err = s.processLeaveAllianceEvent(ctx, e)
+		case kickFromAllianceEventName:
+			err = s.processKickFromAllianceEvent(ctx, e)
+		case queueResearchEventName:
+			err = s.processQueueResearchEvent(ctx, e)
+		case completeResearchEventName:
+			err = s.processCompleteResearchEvent(ctx, e)
+		case regrowVillageEventName:
+			err = s.processRegrowVillageEvent(ctx, e)
+		case createTradeOfferEventName:
+			err = s.processCreateTradeOfferEvent(ctx, e)
+		case acceptTradeOfferEventName:
+			err = s.processAcceptTradeOfferEvent(ctx, e)
+		case cancelTradeOfferEventName:
+			err = s.processCancelTradeOfferEvent(ctx, e)
+		case exchangeResourcesEventName:
+			err = s.processExchangeResourcesEvent(ctx, e)
+		case recallReinforcementsEventName:
+			err = s.processRecallReinforcementsEvent(ctx, e)
 		default:
 			err = fmt.Errorf("%w, event %s, reason: %s %s", errPreConditionFailed, e.id, "unkown event name", e.name)
 		}
 		if err != nil {
+			s.chainEvents = s.chainEvents[:pendingChainEvents]
+			s.mails = s.mails[:pendingMails]
 			if errors.Is(err, errPreConditionFailed) {
+				s.reportRejectedEvent(e, err)
 				continue
 			}
 			return err
 		}
 	}
 
+	s.spawnVillages(tSec(s.clock.Now().Unix()))
+
 	// upsert view tables to upsert and clear the maps
-	s.upsertViews(ctx)
+	return s.upsertViews(ctx)
+}
 
-	return nil
+// spawnVillages spawns the barbarian villages of every config version in effect
+// up to the epoch that did not spawn them yet, as of when the version became
+// effective. Villages only spawn on locations no city is on.
+func (s *EventSourcer) spawnVillages(epoch tSec) {
+	versions := s.configs.until(epoch)
+	for ; s.inMemoryState.spawnedVillageVersions < len(versions); s.inMemoryState.spawnedVillageVersions++ {
+		cfg := versions[s.inMemoryState.spawnedVillageVersions]
+		barbarians := cfg.World.Barbarians
+		for _, location := range cfg.villages {
+			cityID := villageCityID(location.x, location.y)
+			if _, ok := s.inMemoryState.cityList[cityID]; ok || s.inMemoryState.getCityByLocation(location.x, location.y) != nil {
+				continue
+			}
+			buildingsLevel := make(tBuildingsLevel, len(barbarians.Buildings))
+			for buildingName, level := range barbarians.Buildings {
+				buildingsLevel[buildingName] = level
+			}
+			unitCount := make(tUnitsCount, len(barbarians.Garrison))
+			for unitName, count := range barbarians.Garrison {
+				unitCount[unitName] = count
+			}
+			s.inMemoryState.createCity(cityID, &city{
+				id:             cityID,
+				name:           "Barbarian village",
+				playerID:       barbarianPlayerID,
+				locationX:      location.x,
+				locationY:      location.y,
+				buildingsLevel: buildingsLevel,
+				resourceBase:   make(tResourcesCount),
+				resourceEpoch:  min(cfg.effectiveFrom, epoch),
+				unitCount:      unitCount,
+				reinforcements: make(map[tPlayerID]tUnitsCount),
+			})
+			s.inMemoryState.buildingQueuesPerCity[cityID] = make(map[tBuildingQueueItemID]*buildingQueueItem)
+			s.inMemoryState.unitQueuesPerCity[cityID] = make(map[tUnitQueueItemID]*unitQueueItem)
+			s.inMemoryState.researchQueuesPerCity[cityID] = make(map[tResearchQueueItemID]*researchQueueItem)
+			if _, ok := s.spawnedVillageViews[cityID]; !ok {
+				s.spawnedVillageViews[cityID] = struct{}{}
+				s.toUpsert.cities[cityID] = struct{}{}
+			}
+		}
+	}
 }
 
+// upsertViews writes every view changed and every chain event created since the
+// last successful write, on failure they are kept so that the next processed
+// event writes them again. Scores are calculated with the config of the event
+// processed last, a replay scores the past as it was.
 func (s *EventSourcer) upsertViews(ctx context.Context) error {
+	// any change to cities or movements might change the score of its owner
+	for cityID := range s.toUpsert.cities {
+		if c, ok := s.inMemoryState.cityList[cityID]; ok {
+			s.toUpsert.players[c.playerID] = struct{}{}
+		}
+	}
+	for movementID := range s.toUpsert.movements {
+		if m, ok := s.inMemoryState.movementList[movementID]; ok {
+			s.toUpsert.players[m.playerID] = struct{}{}
+		}
+	}
+
+	// barbarians are no player, they have no standing
+	delete(s.toUpsert.players, barbarianPlayerID)
+
+	views := &dbViews{events: s.chainEvents, mails: s.mails}
 	for cityID := range s.toUpsert.cities {
 		c, ok := s.inMemoryState.cityList[cityID]
 		if !ok {
-			err := s.repository.DeleteCity(ctx, string(cityID))
-			if err != nil {
-				return err
-			}
-			err = s.repository.DeleteBuildingQueueItemsFromCity(ctx, string(cityID))
-			if err != nil {
-				return err
-			}
-			err = s.repository.DeleteUnitQueueItemsFromCity(ctx, string(cityID))
-			if err != nil {
-				return err
-			}
+			views.deletedCities = append(views.deletedCities, cityID)
 			continue
 		}
 		dbc, err := cityToDBModel(c)
 		if err != nil {
 			return err
 		}
-		err = s.repository.UpsertCity(ctx, dbc)
-		if err != nil {
-			return err
-		}
+		views.cities = append(views.cities, dbc)
 	}
 	for movementID := range s.toUpsert.movements {
 		m, ok := s.inMemoryState.movementList[movementID]
 		if !ok {
-			err := s.repository.DeleteMovement(ctx, string(movementID))
-			if err != nil {
-				return err
-			}
+			views.deletedMovements = append(views.deletedMovements, movementID)
 			continue
 		}
 		dbm, err := movementToDBModel(m)
 		if err != nil {
 			return err
 		}
-		err = s.repository.UpsertMovement(ctx, dbm)
-		if err != nil {
-			return err
-		}
+		views.movements = append(views.movements, dbm)
 	}
 	for cityID, unitQ := range s.toUpsert.unitQ {
 		for itemID := range unitQ {
 			item, ok := s.inMemoryState.unitQueuesPerCity[cityID][itemID]
 			if !ok {
-				err := s.repository.DeleteUnitQueueItem(ctx, string(itemID))
-				if err != nil {
-					return err
-				}
+				views.deletedUnitQueueItems = append(views.deletedUnitQueueItems, itemID)
 				continue
 			}
-			dbitem := unitQueueItemToDBModel(item)
-			err := s.repository.UpsertUnitQueueItem(ctx, dbitem)
-			if err != nil {
-				return err
-			}
+			views.unitQueueItems = append(views.unitQueueItems, unitQueueItemToDBModel(item))
 		}
 	}
 	for cityID, buildingQ := range s.toUpsert.buildingQ {
 		for itemID := range buildingQ {
 			item, ok := s.inMemoryState.buildingQueuesPerCity[cityID][itemID]
 			if !ok {
-				err := s.repository.DeleteBuildingQueueItem(ctx, string(itemID))
-				if err != nil {
-					return err
-				}
+				views.deletedBuildingQueueItems = append(views.deletedBuildingQueueItems, itemID)
 				continue
 			}
-			dbitem := buildingQueueItemToDBModel(item)
-			err := s.repository.UpsertBuildingQueueItem(ctx, dbitem)
-			if err != nil {
-				return err
+			views.buildingQueueItems = append(views.buildingQueueItems, buildingQueueItemToDBModel(item))
+		}
+	}
+	for cityID, researchQ := range s.toUpsert.researchQ {
+		for itemID := range researchQ {
+			item, ok := s.inMemoryState.researchQueuesPerCity[cityID][itemID]
+			if !ok {
+				views.deletedResearchQueueItems = append(views.deletedResearchQueueItems, itemID)
+				continue
 			}
+			views.researchQueueItems = append(views.researchQueueItems, researchQueueItemToDBModel(item))
+		}
+	}
+	for allianceID := range s.toUpsert.alliances {
+		a, ok := s.inMemoryState.allianceList[allianceID]
+		if !ok {
+			views.deletedAlliances = append(views.deletedAlliances, allianceID)
+			continue
 		}
+		dba, err := allianceToDBModel(a)
+		if err != nil {
+			return err
+		}
+		views.alliances = append(views.alliances, dba)
+	}
+	for playerID := range s.toUpsert.players {
+		research, err := playerResearchToDBModel(s.inMemoryState.researchByPlayer[playerID])
+		if err != nil {
+			return err
+		}
+		views.players = append(views.players, &dbPlayer{
+			id:            playerID,
+			allianceID:    s.inMemoryState.allianceByPlayer[playerID],
+			score:         s.inMemoryState.playerScore(s.cfg, playerID),
+			attackPoints:  s.inMemoryState.attackPoints[playerID],
+			defencePoints: s.inMemoryState.defencePoints[playerID],
+			research:      research,
+		})
+	}
+
+	for tradeOfferID := range s.toUpsert.offers {
+		o, ok := s.inMemoryState.tradeOfferList[tradeOfferID]
+		if !ok {
+			views.deletedTradeOffers = append(views.deletedTradeOffers, tradeOfferID)
+			continue
+		}
+		dbo, err := tradeOfferToDBModel(o)
+		if err != nil {
+			return err
+		}
+		views.tradeOffers = append(views.tradeOffers, dbo)
+	}
+
+	err := s.repository.UpsertViews(ctx, views)
+	if err != nil {
+		return fmt.Errorf("upsert views: %w", err)
 	}
+
+	s.chainEvents = nil
+	for _, d := range s.mails {
+		s.deliveredMails[d.mail.id] = struct{}{}
+	}
+	s.mails = nil
 	s.toUpsert = upsertIDs{
 		cities:    make(map[tCityID]struct{}),
 		movements: make(map[tMovementID]struct{}),
 		unitQ:     make(map[tCityID]map[tUnitQueueItemID]struct{}),
 		buildingQ: make(map[tCityID]map[tBuildingQueueItemID]struct{}),
+		researchQ: make(map[tCityID]map[tResearchQueueItemID]struct{}),
+		alliances: make(map[tAllianceID]struct{}),
+		players:   make(map[tPlayerID]struct{}),
+		offers:    make(map[tTradeOfferID]struct{}),
 	}
 	return nil
 }
@@ -315,20 +611,54 @@ func (s *EventSourcer) processStartMovementEvent(ctx context.Context, e *event)
 	}
 
 	// validation and event calculations
-	if s.inMemoryState.cityList[startMovement.OriginID].playerID != startMovement.PlayerID {
+	originCity, ok := s.inMemoryState.cityList[startMovement.OriginID]
+	if !ok || originCity.playerID != startMovement.PlayerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
 	}
-	if startMovement.OriginID == startMovement.DestinationID {
+	if startMovement.OriginID == startMovement.DestinationID ||
+		(startMovement.DestinationX == originCity.locationX && startMovement.DestinationY == originCity.locationY) {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot move to the same city")
 	}
-	err = reCityCalculateResources(startMovement.DepartureEpoch, startMovement.ResourceCount, s.inMemoryState.cityList[startMovement.OriginID])
+	err = s.cfg.checkLocation(startMovement.DestinationX, startMovement.DestinationY)
 	if err != nil {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
 	}
-
+	for unitName, unitCount := range startMovement.UnitCount {
+		if unitCount <= 0 {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, fmt.Sprintf("non positive %s units", unitName))
+		}
+	}
+	for resourceName, resourceCount := range startMovement.ResourceCount {
+		if resourceCount < 0 {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, fmt.Sprintf("negative %s resources", resourceName))
+		}
+	}
+	movementType := startMovement.MovementType
+	if movementType == "" {
+		movementType = troopsMovement
+	}
+	switch movementType {
+	case troopsMovement:
+	case transportMovement:
+		if len(startMovement.UnitCount) > 0 {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "transports carry no units")
+		}
+		load, err := s.cfg.resourcesLoad(startMovement.ResourceCount)
+		if err != nil {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+		}
+		if load == 0 {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "nothing to transport")
+		}
+		if s.inMemoryState.merchantsInUse(originCity.id)+load > s.cfg.merchantCapacity(originCity.buildingsLevel) {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "not enough merchants")
+		}
+	default:
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, fmt.Sprintf("unknown movement type %s", movementType))
+	}
 	insufficientUnits := ""
 	for unitName, unitCount := range startMovement.UnitCount {
-		if s.inMemoryState.cityList[startMovement.OriginID].unitCount[unitName] > unitCount {
+		if originCity.unitCount[unitName] > unitCount {
 			continue
 		}
 		insufficientUnits += fmt.Sprintf("missing %s units", unitName)
@@ -336,13 +666,28 @@ func (s *EventSourcer) processStartMovementEvent(ctx context.Context, e *event)
 	if insufficientUnits != "" {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, insufficientUnits)
 	}
+	err = s.configs.reCityCalculateResources(startMovement.DepartureEpoch, startMovement.ResourceCount, originCity)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+
 	for unitName, unitCount := range startMovement.UnitCount {
-		s.inMemoryState.cityList[startMovement.OriginID].unitCount[unitName] -= unitCount
+		originCity.unitCount[unitName] -= unitCount
+	}
+	// attacking another player is the end of the beginner protection, the barbarians are fair game
+	if target := s.inMemoryState.getCityByLocation(startMovement.DestinationX, startMovement.DestinationY); target != nil &&
+		movementType == troopsMovement &&
+		target.playerID != startMovement.PlayerID &&
+		target.playerID != barbarianPlayerID &&
+		!s.inMemoryState.areAllies(startMovement.PlayerID, target.playerID) {
+		for _, cityID := range s.inMemoryState.endProtection(startMovement.PlayerID, e.epoch) {
+			s.toUpsert.cities[cityID] = struct{}{}
+		}
 	}
-	speed := getGroupMovementSpeed(startMovement.UnitCount)
-	travelDurationSec := travelTime(
-		s.inMemoryState.cityList[startMovement.OriginID].locationX,
-		s.inMemoryState.cityList[startMovement.OriginID].locationY,
+	speed := s.cfg.movementSpeed(movementType, startMovement.UnitCount)
+	travelDurationSec := s.cfg.travelTime(
+		originCity.locationX,
+		originCity.locationY,
 		startMovement.DestinationX,
 		startMovement.DestinationY,
 		speed,
@@ -358,21 +703,19 @@ func (s *EventSourcer) processStartMovementEvent(ctx context.Context, e *event)
 		DestinationY:  startMovement.DestinationY,
 		UnitCount:     startMovement.UnitCount,
 		ResourceCount: startMovement.ResourceCount,
+		MovementType:  movementType,
 	}
 	payload, err := json.Marshal(arrival)
 	if err != nil {
 		return err
 	}
 	chainEvent := &event{
-		id:      tEventID(uuid.NewString()),
+		id:      chainEventID(e.id, arrivalMovementEventName),
 		name:    arrivalMovementEventName,
 		epoch:   startMovement.DepartureEpoch + travelDurationSec,
 		payload: string(payload),
 	}
-	err = s.repository.InsertEvent(ctx, chainEvent)
-	if err != nil {
-		return err
-	}
+	s.chainEvents = append(s.chainEvents, chainEvent)
 
 	// upsert cached table and signal future view table upsert
 	m := &movement{
@@ -386,8 +729,9 @@ func (s *EventSourcer) processStartMovementEvent(ctx context.Context, e *event)
 		speed:          speed,
 		resourceCount:  startMovement.ResourceCount,
 		unitCount:      startMovement.UnitCount,
+		movementType:   movementType,
 	}
-	s.inMemoryState.movementList[startMovement.MovementID] = m
+	s.inMemoryState.addMovement(m)
 	s.toUpsert.movements[startMovement.MovementID] = struct{}{}
 	s.toUpsert.cities[startMovement.OriginID] = struct{}{}
 
@@ -395,9 +739,11 @@ func (s *EventSourcer) processStartMovementEvent(ctx context.Context, e *event)
 }
 
 // The arrival processing does the options:
-// * reinforce if it's from the same player;
+// * deliver the resources of a transport to the city there, whoever it belongs to;
+// * reinforce if it's from the same player or an ally, allied units stay until recalled or the alliance ends;
+// * bounce if it's from a separate player and the city is protected, insert returnMovementEvent;
 // * battle if it's from separate player and insert returnMovementEvent if troops survive;
-// * forage if it's abandoned and insert returnMovementEvent;
+// * forage if it's abandoned and insert returnMovementEvent, transports just turn back;
 // * create a new city if the unit type sent has the capability for settling;
 func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event) error {
 	// parsing
@@ -408,49 +754,59 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 	}
 
 	// validation and event calculations
-	destinationID := arrivalMovement.DestinationID
+	// the destination is whatever is at the location by now, the city might be gone
+	var destinationID tCityID
 	if c := s.inMemoryState.getCityByLocation(arrivalMovement.DestinationX, arrivalMovement.DestinationY); c != nil {
 		destinationID = tCityID(c.id)
 	}
+	// the dice are seeded by the event so that re-processing it has the same outcome
+	dice := eventRand(e.id)
+	originCity, ok := s.inMemoryState.cityList[arrivalMovement.OriginID]
 
 	switch {
+	case destinationID == "" && !ok:
+		// nothing to forage for when there is no city to return to, everything in movement is lost
+		s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
+
 	case destinationID == "":
 		// TODO ensure this does not overflow or avoid int64 for resource calculations (re-type it)
 		var (
 			freeCarryCapacity tResourceCount
 		)
 		for unitType, unitCount := range arrivalMovement.UnitCount {
-			freeCarryCapacity += tResourceCount(unitCount) * cfg.Units[tUnitName(unitType)].CarryCapacity
+			freeCarryCapacity += tResourceCount(unitCount) * s.cfg.Units[tUnitName(unitType)].CarryCapacity
 		}
 		for _, resourceCount := range arrivalMovement.ResourceCount {
 			freeCarryCapacity -= resourceCount
 		}
 		if freeCarryCapacity > 1 {
-			foragableResources := tResourceCount(rand.Float64() * cfg.ForagingCoefficient * float64(freeCarryCapacity))
-			// FIXME: maps are not ordered, but is it random enough?
-			for resourceName, resourceCount := range arrivalMovement.ResourceCount {
-				foragedResource := tResourceCount(rand.Int63n(int64(foragableResources)))
-				arrivalMovement.ResourceCount[resourceName] = resourceCount + foragedResource
+			foragableResources := tResourceCount(dice.Float64() * s.cfg.ForagingCoefficient * float64(freeCarryCapacity))
+			for _, resourceName := range sortedKeys(arrivalMovement.ResourceCount) {
+				if foragableResources <= 0 {
+					break
+				}
+				foragedResource := tResourceCount(dice.Int63n(int64(foragableResources)))
+				arrivalMovement.ResourceCount[resourceName] += foragedResource
 				foragableResources -= foragedResource
 			}
 		}
 
-		originCity := s.inMemoryState.cityList[arrivalMovement.OriginID]
-
-		speed := getGroupMovementSpeed(arrivalMovement.UnitCount)
-		travelDurationSec := travelTime(
-			arrivalMovement.DestinationY,
+		speed := s.cfg.movementSpeed(arrivalMovement.MovementType, arrivalMovement.UnitCount)
+		travelDurationSec := s.cfg.travelTime(
+			arrivalMovement.DestinationX,
 			arrivalMovement.DestinationY,
 			originCity.locationX,
 			originCity.locationY,
 			speed,
 		)
 
-		s.inMemoryState.movementList[arrivalMovement.MovementID].originID = destinationID
+		s.inMemoryState.setMovementOrigin(s.inMemoryState.movementList[arrivalMovement.MovementID], destinationID)
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationID = arrivalMovement.OriginID
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationX = originCity.locationX
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationY = originCity.locationY
 		s.inMemoryState.movementList[arrivalMovement.MovementID].resourceCount = arrivalMovement.ResourceCount
+		s.inMemoryState.movementList[arrivalMovement.MovementID].departureEpoch = e.epoch
+		s.inMemoryState.movementList[arrivalMovement.MovementID].speed = speed
 
 		// insert chain events
 		returnMovement := &returnMovementEvent{
@@ -469,30 +825,96 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 			return err
 		}
 		chainEvent := &event{
-			id:      tEventID(uuid.NewString()),
+			id:      chainEventID(e.id, returnMovementEventName),
 			name:    returnMovementEventName,
 			epoch:   e.epoch + travelDurationSec,
 			payload: string(payload),
 		}
-		err = s.repository.InsertEvent(ctx, chainEvent)
-		if err != nil {
-			return err
-		}
+		s.chainEvents = append(s.chainEvents, chainEvent)
 
-	case arrivalMovement.PlayerID == tPlayerID(s.inMemoryState.cityList[destinationID].playerID):
+	case arrivalMovement.MovementType == transportMovement:
+		// merchants neither fight nor are turned back, be it a trade or a gift
 		for resourceName, resourceTransported := range arrivalMovement.ResourceCount {
 			s.inMemoryState.cityList[destinationID].resourceBase[resourceName] += resourceTransported
 		}
+		s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
+		s.toUpsert.cities[destinationID] = struct{}{}
+
+	case arrivalMovement.PlayerID == tPlayerID(s.inMemoryState.cityList[destinationID].playerID),
+		s.inMemoryState.areAllies(arrivalMovement.PlayerID, s.inMemoryState.cityList[destinationID].playerID):
+		destinationCity := s.inMemoryState.cityList[destinationID]
+		for resourceName, resourceTransported := range arrivalMovement.ResourceCount {
+			destinationCity.resourceBase[resourceName] += resourceTransported
+		}
+		// the units of an ally are stationed apart, they are still the ally's to count
+		stationed := s.inMemoryState.stationedUnits(destinationCity, arrivalMovement.PlayerID)
 		for unitName, reinforcementCount := range arrivalMovement.UnitCount {
-			s.inMemoryState.cityList[destinationID].unitCount[unitName] += reinforcementCount
+			stationed[unitName] += reinforcementCount
 		}
 		// upsert cached table and signal future view table upsert
-		delete(s.inMemoryState.movementList, arrivalMovement.MovementID)
+		s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
 		s.toUpsert.cities[destinationID] = struct{}{}
 
-	case arrivalMovement.PlayerID != tPlayerID(s.inMemoryState.cityList[destinationID].playerID):
-		// TODO: future aliances possibility and treat this as a permanent reinforcement instead of an attack (?)
+	case s.inMemoryState.cityList[destinationID].protectedUntil > e.epoch:
+		defenderCity := s.inMemoryState.cityList[destinationID]
+		s.sendSystemMail(
+			tMailID("protection-"+e.id),
+			e.epoch,
+			fmt.Sprintf("Protected city: %s", defenderCity.name),
+			fmt.Sprintf(
+				"The city of %s is under beginner protection until %d, the units of %s turned back: %s",
+				defenderCity.playerID, defenderCity.protectedUntil, arrivalMovement.PlayerID, formatUnitsCount(arrivalMovement.UnitCount),
+			),
+			arrivalMovement.PlayerID,
+			defenderCity.playerID,
+		)
+		if !ok {
+			// nowhere to turn back to, everything in movement is lost
+			s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
+			break
+		}
+
+		speed := s.cfg.movementSpeed(arrivalMovement.MovementType, arrivalMovement.UnitCount)
+		travelDurationSec := s.cfg.travelTime(
+			arrivalMovement.DestinationX,
+			arrivalMovement.DestinationY,
+			originCity.locationX,
+			originCity.locationY,
+			speed,
+		)
+
+		s.inMemoryState.setMovementOrigin(s.inMemoryState.movementList[arrivalMovement.MovementID], destinationID)
+		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationID = arrivalMovement.OriginID
+		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationX = originCity.locationX
+		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationY = originCity.locationY
+		s.inMemoryState.movementList[arrivalMovement.MovementID].departureEpoch = e.epoch
+		s.inMemoryState.movementList[arrivalMovement.MovementID].speed = speed
+
+		// insert chain events
+		returnMovement := &returnMovementEvent{
+			MovementID: arrivalMovement.MovementID,
+			PlayerID:   arrivalMovement.PlayerID,
+			// switch origin and destination
+			OriginID:      arrivalMovement.DestinationID,
+			DestinationID: arrivalMovement.OriginID,
+			DestinationX:  originCity.locationX,
+			DestinationY:  originCity.locationY,
+			UnitCount:     arrivalMovement.UnitCount,
+			ResourceCount: arrivalMovement.ResourceCount,
+		}
+		payload, err := json.Marshal(returnMovement)
+		if err != nil {
+			return err
+		}
+		chainEvent := &event{
+			id:      chainEventID(e.id, returnMovementEventName),
+			name:    returnMovementEventName,
+			epoch:   e.epoch + travelDurationSec,
+			payload: string(payload),
+		}
+		s.chainEvents = append(s.chainEvents, chainEvent)
 
+	case arrivalMovement.PlayerID != tPlayerID(s.inMemoryState.cityList[destinationID].playerID):
 		// TODO: a more balanced battle system (research how it is usually done)
 		// and use GPU for matrix calculations maybe?
 		var (
@@ -507,21 +929,39 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 
 		attackerStats := make(map[tUnitStatName]tUnitStatPower)
 		for unitName, unitCount := range attackers {
-			for statName, statValue := range cfg.Units[tUnitName(unitName)].CombatStats {
+			for statName, statValue := range s.cfg.Units[tUnitName(unitName)].CombatStats {
+				statValue = tUnitStatPower(float64(statValue) * s.inMemoryState.statMultiplier(s.cfg, arrivalMovement.PlayerID, statName))
 				attackerStats[statName] += statValue * tUnitStatPower(unitCount)
 				swingMax += statValue * tUnitStatPower(unitCount)
 			}
 		}
+		attackersBefore := make(tUnitsCount, len(attackers))
+		for unitName, unitCount := range attackers {
+			attackersBefore[unitName] = unitCount
+		}
+		// the allies stationed in the city defend it along with its own units, each with their research
+		defenders := make(map[tPlayerID]tUnitsCount, len(defenderCity.reinforcements)+1)
+		for playerID, unitCount := range defenderCity.reinforcements {
+			defenders[playerID] = unitCount
+		}
+		defenders[defenderCity.playerID] = defenderCity.unitCount
+		defendersPowerBefore := make(map[tPlayerID]int64, len(defenders))
+		defendersBefore := make(tUnitsCount, len(defenderCity.unitCount))
 		defendersStats := make(map[tUnitStatName]tUnitStatPower)
-		for unitName, unitCount := range defenderCity.unitCount {
-			for statName, statValue := range cfg.Units[tUnitName(unitName)].CombatStats {
-				defendersStats[statName] += statValue * tUnitStatPower(unitCount)
-				swingMin -= statValue * tUnitStatPower(unitCount)
+		for playerID, defenderUnits := range defenders {
+			defendersPowerBefore[playerID] = s.cfg.unitsPower(defenderUnits)
+			for unitName, unitCount := range defenderUnits {
+				defendersBefore[unitName] += unitCount
+				for statName, statValue := range s.cfg.Units[tUnitName(unitName)].CombatStats {
+					statValue = tUnitStatPower(float64(statValue) * s.inMemoryState.statMultiplier(s.cfg, playerID, statName))
+					defendersStats[statName] += statValue * tUnitStatPower(unitCount)
+					swingMin -= statValue * tUnitStatPower(unitCount)
+				}
 			}
 		}
 
-		for statName := range attackerStats {
-			swing += float64(attackerStats[statName])*(cfg.CombatEfficiency+(1-cfg.CombatEfficiency)*rand.Float64()) - float64(defendersStats[statName])*(cfg.CombatEfficiency+(1-cfg.CombatEfficiency)*rand.Float64())
+		for _, statName := range sortedKeys(attackerStats) {
+			swing += float64(attackerStats[statName])*(s.cfg.CombatEfficiency+(1-s.cfg.CombatEfficiency)*dice.Float64()) - float64(defendersStats[statName])*(s.cfg.CombatEfficiency+(1-s.cfg.CombatEfficiency)*dice.Float64())
 		}
 
 		normalizedSwing := 0.5 * swing / float64(swingMax-swingMin)
@@ -534,26 +974,85 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 				attackers[unitName] = 0
 			}
 		default:
-			for unitName, unitCount := range defenderCity.unitCount {
-				defenderCity.unitCount[unitName] = tUnitCount(float64(unitCount) * (.5 - normalizedSwing))
+			for _, defenderUnits := range defenders {
+				for unitName, unitCount := range defenderUnits {
+					defenderUnits[unitName] = tUnitCount(float64(unitCount) * (.5 - normalizedSwing))
+				}
 			}
 			for unitName, unitCount := range attackers {
 				attackers[unitName] = tUnitCount(float64(unitCount) * (.5 + normalizedSwing))
 			}
 		}
+		defendersAfter := make(tUnitsCount, len(defendersBefore))
+		for _, defenderUnits := range defenders {
+			for unitName, unitCount := range defenderUnits {
+				defendersAfter[unitName] += unitCount
+			}
+		}
 		var (
 			attackersFreeCapacity tResourceCount
 			liveAttackers         bool
 		)
 		for unitType, unitCount := range attackers {
-			attackersFreeCapacity += tResourceCount(unitCount) * cfg.Units[tUnitName(unitType)].CarryCapacity
+			attackersFreeCapacity += tResourceCount(unitCount) * s.cfg.Units[tUnitName(unitType)].CarryCapacity
 			if unitCount == 0 {
 				continue
 			}
 			liveAttackers = true
 		}
 		s.toUpsert.cities[destinationID] = struct{}{} // upsert attacked city regardless
-		if !liveAttackers {
+		s.sendSystemMail(
+			tMailID("battle-"+e.id),
+			epoch,
+			fmt.Sprintf("Battle report: %s", defenderCity.name),
+			fmt.Sprintf(
+				"Attackers of %s: %s -> %s\nDefenders of %s: %s -> %s",
+				arrivalMovement.PlayerID, formatUnitsCount(attackersBefore), formatUnitsCount(attackers),
+				defenderCity.playerID, formatUnitsCount(defendersBefore), formatUnitsCount(defendersAfter),
+			),
+			arrivalMovement.PlayerID,
+			defenderCity.playerID,
+		)
+		// kill points are awarded by the combat power taken down from the other side, the
+		// defenders share theirs by the combat power each brought to the battle
+		s.inMemoryState.attackPoints[arrivalMovement.PlayerID] += s.cfg.unitsPower(defendersBefore) - s.cfg.unitsPower(defendersAfter)
+		s.toUpsert.players[arrivalMovement.PlayerID] = struct{}{}
+		defendersPower := s.cfg.unitsPower(defendersBefore)
+		for playerID := range defenders {
+			if defendersPower > 0 {
+				s.inMemoryState.defencePoints[playerID] += (s.cfg.unitsPower(attackersBefore) - s.cfg.unitsPower(attackers)) * defendersPowerBefore[playerID] / defendersPower
+			}
+			s.toUpsert.players[playerID] = struct{}{}
+		}
+
+		if defenderCity.playerID == barbarianPlayerID {
+			// a village left with nobody to fight for it is taken over by the attackers, who stay to hold it
+			if liveAttackers && s.cfg.World.Barbarians.Conquerable && s.cfg.unitsPower(defenderCity.unitCount) == 0 {
+				err = s.configs.reCityCalculateResources(epoch, make(tResourcesCount), defenderCity)
+				if err != nil {
+					return err
+				}
+				s.inMemoryState.setCityOwner(defenderCity, arrivalMovement.PlayerID)
+				for unitName, unitCount := range attackers {
+					defenderCity.unitCount[unitName] += unitCount
+				}
+				for resourceName, resourceCount := range initialLoad {
+					defenderCity.resourceBase[resourceName] += resourceCount
+				}
+				s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
+				s.toUpsert.movements[arrivalMovement.MovementID] = struct{}{}
+				return nil
+			}
+			err = s.scheduleVillageRegrowth(e, defenderCity)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !liveAttackers || !ok {
+			// survivors with no city to return to are lost as well
+			s.inMemoryState.deleteMovement(arrivalMovement.MovementID)
+			s.toUpsert.movements[arrivalMovement.MovementID] = struct{}{}
 			return nil
 		}
 
@@ -562,17 +1061,24 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 		}
 
 		// TODO: do not utilize this hack to make it re-calculate the epoch and current base
-		reCityCalculateResources(epoch, make(tResourcesCount), defenderCity)
+		err = s.configs.reCityCalculateResources(epoch, make(tResourcesCount), defenderCity)
+		if err != nil {
+			return err
+		}
 
 		if attackersFreeCapacity < 0 {
 			// edge case: attackers bring resources to the defenders! inverted plunder
 			resourcesToLeave := -attackersFreeCapacity / tResourceCount(len(initialLoad))
 			negativeCost := make(tResourcesCount)
 			for resourceName, resourceCount := range initialLoad {
-				initialLoad[resourceName] = resourceCount - resourcesToLeave
-				negativeCost[resourceName] = -resourcesToLeave
+				resourceToLeave := min(resourcesToLeave, resourceCount)
+				initialLoad[resourceName] = resourceCount - resourceToLeave
+				negativeCost[resourceName] = -resourceToLeave
+			}
+			err = s.configs.reCityCalculateResources(epoch, negativeCost, defenderCity)
+			if err != nil {
+				return err
 			}
-			reCityCalculateResources(epoch, negativeCost, defenderCity)
 		} else if attackersFreeCapacity > 0 {
 			resourcesToPlunderPerType := attackersFreeCapacity / tResourceCount(len(defenderCity.resourceBase))
 			for resourceName, resourceCount := range defenderCity.resourceBase {
@@ -586,9 +1092,9 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 					attackersFreeCapacity -= resourceCount
 				}
 			}
-			// NOTE: do not rely on randomness of map key access for this second round ?
 			if attackersFreeCapacity > 0 {
-				for resourceName, resourceCount := range defenderCity.resourceBase {
+				for _, resourceName := range sortedKeys(defenderCity.resourceBase) {
+					resourceCount := defenderCity.resourceBase[resourceName]
 					if resourceCount == 0 || attackersFreeCapacity == 0 {
 						continue
 					}
@@ -605,22 +1111,22 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 			}
 		}
 
-		originCity := s.inMemoryState.cityList[arrivalMovement.OriginID]
-		speed := getGroupMovementSpeed(arrivalMovement.UnitCount)
-		travelDurationSec := travelTime(
-			arrivalMovement.DestinationY,
+		speed := s.cfg.getGroupMovementSpeed(arrivalMovement.UnitCount)
+		travelDurationSec := s.cfg.travelTime(
+			arrivalMovement.DestinationX,
 			arrivalMovement.DestinationY,
 			originCity.locationX,
 			originCity.locationY,
 			speed,
 		)
 
-		s.inMemoryState.movementList[arrivalMovement.MovementID].originID = destinationID
+		s.inMemoryState.setMovementOrigin(s.inMemoryState.movementList[arrivalMovement.MovementID], destinationID)
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationID = arrivalMovement.OriginID
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationX = originCity.locationX
 		s.inMemoryState.movementList[arrivalMovement.MovementID].destinationY = originCity.locationY
 		s.inMemoryState.movementList[arrivalMovement.MovementID].resourceCount = arrivalMovement.ResourceCount
 		s.inMemoryState.movementList[arrivalMovement.MovementID].unitCount = arrivalMovement.UnitCount
+		s.inMemoryState.movementList[arrivalMovement.MovementID].departureEpoch = e.epoch
 		s.inMemoryState.movementList[arrivalMovement.MovementID].speed = speed
 
 		// insert chain events
@@ -640,15 +1146,12 @@ func (s *EventSourcer) processArrivalMovementEvent(ctx context.Context, e *event
 			return err
 		}
 		chainEvent := &event{
-			id:      tEventID(uuid.NewString()),
+			id:      chainEventID(e.id, returnMovementEventName),
 			name:    returnMovementEventName,
 			epoch:   e.epoch + travelDurationSec,
 			payload: string(payload),
 		}
-		err = s.repository.InsertEvent(ctx, chainEvent)
-		if err != nil {
-			return err
-		}
+		s.chainEvents = append(s.chainEvents, chainEvent)
 	}
 
 	// no matter if it is deleted or it's a returning movement, the movement will
@@ -671,54 +1174,24 @@ func (s *EventSourcer) processReturnMovementEvent(ctx context.Context, e *event)
 
 	// validation and event calculations
 	destinationCity := s.inMemoryState.getCityByLocation(returnMovement.DestinationX, returnMovement.DestinationY)
-	destinationID := tCityID(destinationCity.id)
 
 	switch {
 	case destinationCity == nil:
 		// city where the units left from no longer exists... everything in movement will disappear
-		delete(s.inMemoryState.movementList, returnMovement.MovementID)
+		s.inMemoryState.deleteMovement(returnMovement.MovementID)
 	case destinationCity.playerID != returnMovement.PlayerID:
-		// the city was captured by another player, everything is lost to avoid an eternal pendulum of a huge
+		// the city was conquered while they were away, everything is lost to avoid an eternal pendulum of a huge
 		// army
-		// TODO: don't do this when it is possible to conquer a city
-		delete(s.inMemoryState.movementList, returnMovement.MovementID)
+		s.inMemoryState.deleteMovement(returnMovement.MovementID)
 	default:
-		s.toUpsert.cities[destinationID] = struct{}{} // upsert returned city
-		originCity := s.inMemoryState.cityList[returnMovement.OriginID]
-		speed := getGroupMovementSpeed(returnMovement.UnitCount)
-		travelDurationSec := travelTime(
-			returnMovement.DestinationY,
-			returnMovement.DestinationY,
-			originCity.locationX,
-			originCity.locationY,
-			speed,
-		)
-
-		// insert chain events
-		arrival := &arrivalMovementEvent{
-			MovementID:    returnMovement.MovementID,
-			PlayerID:      returnMovement.PlayerID,
-			OriginID:      returnMovement.OriginID,
-			DestinationID: returnMovement.DestinationID,
-			DestinationX:  returnMovement.DestinationX,
-			DestinationY:  returnMovement.DestinationY,
-			UnitCount:     returnMovement.UnitCount,
-			ResourceCount: returnMovement.ResourceCount,
-		}
-		payload, err := json.Marshal(arrival)
-		if err != nil {
-			return err
-		}
-		chainEvent := &event{
-			id:      tEventID(uuid.NewString()),
-			name:    arrivalMovementEventName,
-			epoch:   e.epoch + travelDurationSec,
-			payload: string(payload),
+		for resourceName, resourceTransported := range returnMovement.ResourceCount {
+			destinationCity.resourceBase[resourceName] += resourceTransported
 		}
-		err = s.repository.InsertEvent(ctx, chainEvent)
-		if err != nil {
-			return err
+		for unitName, unitCount := range returnMovement.UnitCount {
+			destinationCity.unitCount[unitName] += unitCount
 		}
+		s.inMemoryState.deleteMovement(returnMovement.MovementID)
+		s.toUpsert.cities[destinationCity.id] = struct{}{} // upsert returned city
 	}
 	s.toUpsert.movements[returnMovement.MovementID] = struct{}{}
 
@@ -736,21 +1209,32 @@ func (s *EventSourcer) processQueueUnitEventName(ctx context.Context, e *event)
 	}
 
 	// validation and event calculations
-	if s.inMemoryState.cityList[queueUnit.CityID].playerID != queueUnit.PlayerID {
+	c, ok := s.inMemoryState.cityList[queueUnit.CityID]
+	if !ok || c.playerID != queueUnit.PlayerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
 	}
-	err = reCityCalculateResources(e.epoch, cfg.Units[queueUnit.UnitType].UnitCost, s.inMemoryState.cityList[queueUnit.CityID])
+	if queueUnit.UnitCount <= 0 {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "non positive unit count")
+	}
+	if !s.inMemoryState.isUnlocked(queueUnit.PlayerID, s.cfg.unitUnlockedBy[queueUnit.UnitType]) {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unit requires research")
+	}
+	err = s.cfg.checkUnitRequirements(queueUnit.UnitType, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	err = s.configs.reCityCalculateResources(e.epoch, s.cfg.Units[queueUnit.UnitType].UnitCost, c)
 	if err != nil {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
 	}
 
 	// TODO: measure and optimize
 	multiplier := 1.0
-	for _, buildingKey := range cumulativeTrainingMultipliers[queueUnit.UnitType] {
+	for _, buildingKey := range s.cfg.cumulativeTrainingMultipliers[queueUnit.UnitType] {
 		// TODO: formalize these equations to calculate game time ++ pre-compute most of this
-		multiplier *= cfg.Buildings[buildingKey].TrainingMultiplier[s.inMemoryState.cityList[queueUnit.CityID].buildingsLevel[buildingKey]]
+		multiplier *= s.cfg.Buildings[buildingKey].TrainingMultiplier[c.buildingsLevel[buildingKey]]
 	}
-	trainingDurationSec := tSec(float64(cfg.Units[queueUnit.UnitType].UnitProductionSpeedSec*tSec(queueUnit.UnitCount)) * multiplier)
+	trainingDurationSec := s.cfg.scaledDuration(tSec(float64(s.cfg.Units[queueUnit.UnitType].UnitProductionSpeedSec*tSec(queueUnit.UnitCount)) * multiplier))
 
 	// insert chain events
 	createUnit := &createUnitEvent{
@@ -765,27 +1249,25 @@ func (s *EventSourcer) processQueueUnitEventName(ctx context.Context, e *event)
 		return err
 	}
 	chainEvent := &event{
-		id:      tEventID(uuid.NewString()),
+		id:      chainEventID(e.id, createUnitEventName),
 		name:    createUnitEventName,
 		epoch:   e.epoch + trainingDurationSec,
 		payload: string(payload),
 	}
-	err = s.repository.InsertEvent(ctx, chainEvent)
-	if err != nil {
-		return err
-	}
+	s.chainEvents = append(s.chainEvents, chainEvent)
 
 	// upsert cached table and signal future view table upsert
 	queueItem := &unitQueueItem{
 		id:          queueUnit.UnitQueueItemID,
 		cityID:      queueUnit.CityID,
+		playerID:    queueUnit.PlayerID,
 		queuedEpoch: e.epoch,
 		durationSec: trainingDurationSec,
 		unitCount:   queueUnit.UnitCount,
 		unitType:    queueUnit.UnitType,
 	}
 	s.inMemoryState.unitQueuesPerCity[tCityID(queueItem.cityID)][tUnitQueueItemID(queueItem.id)] = queueItem
-	s.toUpsert.unitQ[tCityID(queueItem.cityID)][tUnitQueueItemID(queueItem.id)] = struct{}{}
+	s.toUpsert.addUnitQueueItem(queueItem.cityID, queueItem.id)
 
 	return nil
 }
@@ -800,17 +1282,18 @@ func (s *EventSourcer) processCreateUnitEvent(_ context.Context, e *event) error
 		return err
 	}
 	// validation and event calculations
-	if createUnit.PlayerID != tPlayerID(s.inMemoryState.cityList[createUnit.CityID].playerID) {
+	c, ok := s.inMemoryState.cityList[createUnit.CityID]
+	if !ok || createUnit.PlayerID != c.playerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "city has changed owner")
 	}
-	s.inMemoryState.cityList[createUnit.CityID].unitCount[createUnit.UnitType] += tUnitCount(createUnit.UnitCount)
+	c.unitCount[createUnit.UnitType] += createUnit.UnitCount
 	delete(s.inMemoryState.unitQueuesPerCity[createUnit.CityID], createUnit.UnitQueueItemID)
 
 	// insert chain events
 
 	// upsert cached table and signal future view table upsert
 	s.toUpsert.cities[createUnit.CityID] = struct{}{}
-	s.toUpsert.unitQ[createUnit.CityID][createUnit.UnitQueueItemID] = struct{}{}
+	s.toUpsert.addUnitQueueItem(createUnit.CityID, createUnit.UnitQueueItemID)
 
 	return nil
 }
@@ -824,23 +1307,34 @@ func (s *EventSourcer) processQueueBuildingEvent(ctx context.Context, e *event)
 	}
 
 	// validation and event calculations
-	if s.inMemoryState.cityList[queueBuilding.CityID].playerID != queueBuilding.PlayerID {
+	c, ok := s.inMemoryState.cityList[queueBuilding.CityID]
+	if !ok || c.playerID != queueBuilding.PlayerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
 	}
-	targetBuildingSpecs := cfg.Buildings[queueBuilding.TargetBuilding]
+	targetBuildingSpecs, ok := s.cfg.Buildings[queueBuilding.TargetBuilding]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unknown building")
+	}
+	if !s.inMemoryState.isUnlocked(queueBuilding.PlayerID, s.cfg.buildingUnlockedBy[queueBuilding.TargetBuilding]) {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "building requires research")
+	}
+	err = s.cfg.checkBuildingRequirements(queueBuilding.TargetBuilding, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
 	if queueBuilding.TargetLevel > targetBuildingSpecs.MaxLevel {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot upgrade past max level")
 	}
-	currentBuildingLevel := s.inMemoryState.cityList[queueBuilding.CityID].buildingsLevel[queueBuilding.TargetBuilding]
-	if queueBuilding.TargetLevel > currentBuildingLevel+1 {
+	currentBuildingLevel := c.buildingsLevel[queueBuilding.TargetBuilding]
+	if queueBuilding.TargetLevel != currentBuildingLevel+1 {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "only upgrade 1 level at a time")
 	}
 	upgradeCost := targetBuildingSpecs.UpgradeCost[currentBuildingLevel]
-	err = reCityCalculateResources(e.epoch, upgradeCost, s.inMemoryState.cityList[queueBuilding.CityID])
+	err = s.configs.reCityCalculateResources(e.epoch, upgradeCost, c)
 	if err != nil {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
 	}
-	upgradeDurationSec := targetBuildingSpecs.UpgradeSpeed[currentBuildingLevel]
+	upgradeDurationSec := s.cfg.scaledDuration(targetBuildingSpecs.UpgradeSpeed[currentBuildingLevel])
 
 	// insert chain events
 	upgradeBuilding := &upgradeBuildingEvent{
@@ -855,27 +1349,25 @@ func (s *EventSourcer) processQueueBuildingEvent(ctx context.Context, e *event)
 		return err
 	}
 	chainEvent := &event{
-		id:      tEventID(uuid.NewString()),
+		id:      chainEventID(e.id, upgradeBuildingEventName),
 		name:    upgradeBuildingEventName,
 		epoch:   e.epoch + upgradeDurationSec,
 		payload: string(payload),
 	}
-	err = s.repository.InsertEvent(ctx, chainEvent)
-	if err != nil {
-		return err
-	}
+	s.chainEvents = append(s.chainEvents, chainEvent)
 
 	// upsert cached table and signal future view table upsert
 	queueItem := &buildingQueueItem{
 		id:             queueBuilding.BuildingQueueItemID,
 		cityID:         queueBuilding.CityID,
+		playerID:       queueBuilding.PlayerID,
 		queuedEpoch:    e.epoch,
 		durationSec:    upgradeDurationSec,
 		targetLevel:    queueBuilding.TargetLevel,
 		targetBuilding: queueBuilding.TargetBuilding,
 	}
 	s.inMemoryState.buildingQueuesPerCity[tCityID(queueItem.cityID)][queueItem.id] = queueItem
-	s.toUpsert.buildingQ[tCityID(queueItem.cityID)][queueItem.id] = struct{}{}
+	s.toUpsert.addBuildingQueueItem(queueItem.cityID, queueItem.id)
 
 	return nil
 }
@@ -891,22 +1383,23 @@ func (s *EventSourcer) processUpgradeBuildingEvent(_ context.Context, e *event)
 	}
 
 	// validation and event calculations
-	if s.inMemoryState.cityList[upgradeBuilding.CityID].playerID != upgradeBuilding.PlayerID {
+	c, ok := s.inMemoryState.cityList[upgradeBuilding.CityID]
+	if !ok || c.playerID != upgradeBuilding.PlayerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
 	}
 	// HACK: pass a zero cost event to re-calculate the base and increment the epoch
-	err = reCityCalculateResources(e.epoch, make(tResourcesCount), s.inMemoryState.cityList[upgradeBuilding.CityID])
+	err = s.configs.reCityCalculateResources(e.epoch, make(tResourcesCount), c)
 	if err != nil {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
 	}
-	s.inMemoryState.cityList[upgradeBuilding.CityID].buildingsLevel[upgradeBuilding.TargetBuilding] = upgradeBuilding.TargetLevel
+	c.buildingsLevel[upgradeBuilding.TargetBuilding] = upgradeBuilding.TargetLevel
 	delete(s.inMemoryState.buildingQueuesPerCity[upgradeBuilding.CityID], upgradeBuilding.BuildingQueueItemID)
 
 	// insert chain events
 
 	// upsert cached table and signal future view table upsert
 	s.toUpsert.cities[upgradeBuilding.CityID] = struct{}{}
-	s.toUpsert.buildingQ[upgradeBuilding.CityID][upgradeBuilding.BuildingQueueItemID] = struct{}{}
+	s.toUpsert.addBuildingQueueItem(upgradeBuilding.CityID, upgradeBuilding.BuildingQueueItemID)
 
 	return nil
 }
@@ -929,6 +1422,17 @@ func (s *EventSourcer) processCreateCityEvent(_ context.Context, e *event) error
 	if s.inMemoryState.getCityByLocation(createCity.LocationX, createCity.LocationY) != nil {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unexpected occupied location")
 	}
+	err = s.cfg.checkLocation(createCity.LocationX, createCity.LocationY)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+
+	// the first city a player ever settles starts the beginner protection, the
+	// others share it, even when the first one is long gone
+	protectedUntil, firstCity := s.inMemoryState.playerProtectedUntil(createCity.PlayerID)
+	if !firstCity {
+		protectedUntil = e.epoch + s.cfg.scaledDuration(s.cfg.BeginnerProtection)
+	}
 
 	// insert chain events
 
@@ -946,15 +1450,20 @@ func (s *EventSourcer) processCreateCityEvent(_ context.Context, e *event) error
 		resourceBase:   createCity.ResourceCount,
 		resourceEpoch:  e.epoch,
 		unitCount:      createCity.UnitCount,
+		protectedUntil: protectedUntil,
+		reinforcements: make(map[tPlayerID]tUnitsCount),
 	})
 	s.inMemoryState.buildingQueuesPerCity[createCity.CityID] = make(map[tBuildingQueueItemID]*buildingQueueItem)
 	s.inMemoryState.unitQueuesPerCity[createCity.CityID] = make(map[tUnitQueueItemID]*unitQueueItem)
+	s.inMemoryState.researchQueuesPerCity[createCity.CityID] = make(map[tResearchQueueItemID]*researchQueueItem)
+	s.inMemoryState.startProtection(createCity.PlayerID, protectedUntil)
 	s.toUpsert.cities[tCityID(createCity.CityID)] = struct{}{}
 	return nil
 }
 
 // Its processing will effectively delete the city, vanquish the resources,
-// raze the buildings, and annihilate all units residing the city.
+// raze the buildings, and annihilate all units residing the city. The units of
+// allies stationed there are not the owner's to lose, they go back home.
 func (s *EventSourcer) processDeleteCityEvent(_ context.Context, e *event) error {
 	// parsing
 	deleteCity := deleteCityEvent{}
@@ -964,34 +1473,778 @@ func (s *EventSourcer) processDeleteCityEvent(_ context.Context, e *event) error
 	}
 
 	// validation and event calculations
-	if s.inMemoryState.cityList[deleteCity.CityID].playerID != deleteCity.PlayerID {
+	c, ok := s.inMemoryState.cityList[deleteCity.CityID]
+	if !ok || c.playerID != deleteCity.PlayerID {
 		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot delete cities of other players")
 	}
 
 	// insert chain events
-
-	// upsert cached table and signal future view table upsert
-	s.inMemoryState.deleteCity(deleteCity.CityID)
+	for _, allyID := range sortedKeys(c.reinforcements) {
+		err = s.sendReinforcementsHome(e, c, allyID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the resources held by the open offers of the city are gone with it
+	for tradeOfferID := range s.inMemoryState.tradeOffersByCity[deleteCity.CityID] {
+		s.inMemoryState.deleteTradeOffer(tradeOfferID)
+		s.toUpsert.offers[tradeOfferID] = struct{}{}
+	}
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.deleteCity(deleteCity.CityID)
 	s.toUpsert.cities[tCityID(deleteCity.CityID)] = struct{}{}
+	s.toUpsert.players[deleteCity.PlayerID] = struct{}{}
 	return nil
 }
 
-func reCityCalculateResources(epoch tSec, cost tResourcesCount, c *city) error {
-	missingResources := ""
-	for resourceName, resourceCost := range cost {
-		if c.resourceBase[resourceName] > resourceCost {
+// The alliance is created with the creating player as its leader and only member.
+// A player can only belong to one alliance at a time.
+func (s *EventSourcer) processCreateAllianceEvent(_ context.Context, e *event) error {
+	// parsing
+	createAlliance := createAllianceEvent{}
+	err := json.Unmarshal([]byte(e.payload), &createAlliance)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	if _, ok := s.inMemoryState.allianceList[createAlliance.AllianceID]; ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unexpected repeated allianceID")
+	}
+	if _, ok := s.inMemoryState.allianceByPlayer[createAlliance.PlayerID]; ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player already belongs to an alliance")
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.allianceList[createAlliance.AllianceID] = &alliance{
+		id:       createAlliance.AllianceID,
+		name:     createAlliance.Name,
+		leaderID: createAlliance.PlayerID,
+		invites:  make(map[tPlayerID]struct{}),
+		members:  make(map[tPlayerID]struct{}),
+	}
+	s.inMemoryState.joinAlliance(createAlliance.AllianceID, createAlliance.PlayerID)
+	s.toUpsert.alliances[createAlliance.AllianceID] = struct{}{}
+	s.toUpsert.players[createAlliance.PlayerID] = struct{}{}
+	return nil
+}
+
+// Only the alliance leader is able to invite other players. The invite stays
+// pending until the invitee joins the alliance.
+func (s *EventSourcer) processInviteToAllianceEvent(_ context.Context, e *event) error {
+	// parsing
+	inviteToAlliance := inviteToAllianceEvent{}
+	err := json.Unmarshal([]byte(e.payload), &inviteToAlliance)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	a, ok := s.inMemoryState.allianceList[inviteToAlliance.AllianceID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "alliance does not exist")
+	}
+	if a.leaderID != inviteToAlliance.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "only the alliance leader can invite")
+	}
+	if _, ok := a.members[inviteToAlliance.InviteeID]; ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player is already a member")
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	a.invites[inviteToAlliance.InviteeID] = struct{}{}
+	s.toUpsert.alliances[inviteToAlliance.AllianceID] = struct{}{}
+	return nil
+}
+
+// The player joins the alliance if it was invited and does not belong to another alliance.
+func (s *EventSourcer) processJoinAllianceEvent(_ context.Context, e *event) error {
+	// parsing
+	joinAlliance := joinAllianceEvent{}
+	err := json.Unmarshal([]byte(e.payload), &joinAlliance)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	a, ok := s.inMemoryState.allianceList[joinAlliance.AllianceID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "alliance does not exist")
+	}
+	if _, ok := a.invites[joinAlliance.PlayerID]; !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player was not invited")
+	}
+	if _, ok := s.inMemoryState.allianceByPlayer[joinAlliance.PlayerID]; ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player already belongs to an alliance")
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.joinAlliance(joinAlliance.AllianceID, joinAlliance.PlayerID)
+	s.toUpsert.alliances[joinAlliance.AllianceID] = struct{}{}
+	s.toUpsert.players[joinAlliance.PlayerID] = struct{}{}
+	return nil
+}
+
+// The player leaves the alliance. If the leader leaves, the leadership is passed
+// to the remaining member with the lowest player ID; if no members remain the
+// alliance is disbanded.
+func (s *EventSourcer) processLeaveAllianceEvent(_ context.Context, e *event) error {
+	// parsing
+	leaveAlliance := leaveAllianceEvent{}
+	err := json.Unmarshal([]byte(e.payload), &leaveAlliance)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	a, ok := s.inMemoryState.allianceList[leaveAlliance.AllianceID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "alliance does not exist")
+	}
+	if _, ok := a.members[leaveAlliance.PlayerID]; !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player is not a member")
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.leaveAlliance(leaveAlliance.AllianceID, leaveAlliance.PlayerID)
+	err = s.sendFormerAlliesHome(e, leaveAlliance.PlayerID)
+	if err != nil {
+		return err
+	}
+	if a.leaderID == leaveAlliance.PlayerID {
+		a.leaderID = ""
+		for playerID := range a.members {
+			if a.leaderID == "" || playerID < a.leaderID {
+				a.leaderID = playerID
+			}
+		}
+	}
+	if len(a.members) == 0 {
+		delete(s.inMemoryState.allianceList, leaveAlliance.AllianceID)
+	}
+	s.toUpsert.alliances[leaveAlliance.AllianceID] = struct{}{}
+	s.toUpsert.players[leaveAlliance.PlayerID] = struct{}{}
+	return nil
+}
+
+// Only the alliance leader is able to kick other members out of the alliance.
+func (s *EventSourcer) processKickFromAllianceEvent(_ context.Context, e *event) error {
+	// parsing
+	kickFromAlliance := kickFromAllianceEvent{}
+	err := json.Unmarshal([]byte(e.payload), &kickFromAlliance)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	a, ok := s.inMemoryState.allianceList[kickFromAlliance.AllianceID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "alliance does not exist")
+	}
+	if a.leaderID != kickFromAlliance.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "only the alliance leader can kick")
+	}
+	if kickFromAlliance.MemberID == kickFromAlliance.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "the leader cannot kick itself")
+	}
+	if _, ok := a.members[kickFromAlliance.MemberID]; !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "player is not a member")
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.leaveAlliance(kickFromAlliance.AllianceID, kickFromAlliance.MemberID)
+	err = s.sendFormerAlliesHome(e, kickFromAlliance.MemberID)
+	if err != nil {
+		return err
+	}
+	s.toUpsert.alliances[kickFromAlliance.AllianceID] = struct{}{}
+	s.toUpsert.players[kickFromAlliance.MemberID] = struct{}{}
+	return nil
+}
+
+// Research is paid by the city where it is queued but, once completed, it is
+// available to every city of the player. A research cannot be queued twice.
+func (s *EventSourcer) processQueueResearchEvent(ctx context.Context, e *event) error {
+	// parsing
+	queueResearch := queueResearchEvent{}
+	err := json.Unmarshal([]byte(e.payload), &queueResearch)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	c, ok := s.inMemoryState.cityList[queueResearch.CityID]
+	if !ok || c.playerID != queueResearch.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
+	}
+	researchSpecs, ok := s.cfg.Research[queueResearch.ResearchName]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unknown research")
+	}
+	if s.inMemoryState.hasResearched(queueResearch.PlayerID, queueResearch.ResearchName) ||
+		s.inMemoryState.isResearchQueued(queueResearch.PlayerID, queueResearch.ResearchName) {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "research already done or queued")
+	}
+	err = checkRequiredBuildings(researchSpecs.RequiredBuildings, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	for _, researchName := range researchSpecs.RequiredResearch {
+		if !s.inMemoryState.hasResearched(queueResearch.PlayerID, researchName) {
+			return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, fmt.Sprintf("requires research %s", researchName))
+		}
+	}
+	err = s.configs.reCityCalculateResources(e.epoch, researchSpecs.Cost, c)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	researchDurationSec := s.cfg.scaledDuration(researchSpecs.DurationSec)
+
+	// insert chain events
+	completeResearch := &completeResearchEvent{
+		ResearchQueueItemID: queueResearch.ResearchQueueItemID,
+		CityID:              queueResearch.CityID,
+		PlayerID:            queueResearch.PlayerID,
+		ResearchName:        queueResearch.ResearchName,
+	}
+	payload, err := json.Marshal(completeResearch)
+	if err != nil {
+		return err
+	}
+	chainEvent := &event{
+		id:      chainEventID(e.id, completeResearchEventName),
+		name:    completeResearchEventName,
+		epoch:   e.epoch + researchDurationSec,
+		payload: string(payload),
+	}
+	s.chainEvents = append(s.chainEvents, chainEvent)
+
+	// upsert cached table and signal future view table upsert
+	queueItem := &researchQueueItem{
+		id:           queueResearch.ResearchQueueItemID,
+		cityID:       queueResearch.CityID,
+		playerID:     queueResearch.PlayerID,
+		queuedEpoch:  e.epoch,
+		durationSec:  researchDurationSec,
+		researchName: queueResearch.ResearchName,
+	}
+	s.inMemoryState.researchQueuesPerCity[queueItem.cityID][queueItem.id] = queueItem
+	s.toUpsert.addResearchQueueItem(queueItem.cityID, queueItem.id)
+	s.toUpsert.cities[queueItem.cityID] = struct{}{}
+
+	return nil
+}
+
+// If the cityID still belongs to the original player, the research is completed.
+// If the city was conquered by a different player, nothing will happen.
+func (s *EventSourcer) processCompleteResearchEvent(_ context.Context, e *event) error {
+	// parsing
+	completeResearch := completeResearchEvent{}
+	err := json.Unmarshal([]byte(e.payload), &completeResearch)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	c, ok := s.inMemoryState.cityList[completeResearch.CityID]
+	if !ok || c.playerID != completeResearch.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "city has changed owner")
+	}
+	if _, ok := s.inMemoryState.researchByPlayer[completeResearch.PlayerID]; !ok {
+		s.inMemoryState.researchByPlayer[completeResearch.PlayerID] = make(map[tResearchName]struct{})
+	}
+	s.inMemoryState.researchByPlayer[completeResearch.PlayerID][completeResearch.ResearchName] = struct{}{}
+	delete(s.inMemoryState.researchQueuesPerCity[completeResearch.CityID], completeResearch.ResearchQueueItemID)
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.toUpsert.addResearchQueueItem(completeResearch.CityID, completeResearch.ResearchQueueItemID)
+	s.toUpsert.players[completeResearch.PlayerID] = struct{}{}
+
+	return nil
+}
+
+// A village regrows its garrison after being attacked, a regrowth at a time
+// until it is back to what it spawned with. Conquered villages stop regrowing.
+func (s *EventSourcer) processRegrowVillageEvent(_ context.Context, e *event) error {
+	// parsing
+	regrowVillage := regrowVillageEvent{}
+	err := json.Unmarshal([]byte(e.payload), &regrowVillage)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	delete(s.inMemoryState.regrowingVillages, regrowVillage.CityID)
+	village, ok := s.inMemoryState.cityList[regrowVillage.CityID]
+	if !ok || village.playerID != barbarianPlayerID {
+		return nil
+	}
+	barbarians := s.cfg.World.Barbarians
+	for unitName, garrison := range barbarians.Garrison {
+		if village.unitCount[unitName] < garrison {
+			village.unitCount[unitName] = min(garrison, village.unitCount[unitName]+barbarians.Regrowth[unitName])
+		}
+	}
+
+	// insert chain events
+	err = s.scheduleVillageRegrowth(e, village)
+	if err != nil {
+		return err
+	}
+
+	// upsert cached table and signal future view table upsert
+	s.toUpsert.cities[village.id] = struct{}{}
+	return nil
+}
+
+// scheduleVillageRegrowth inserts the next regrowth of a village, unless there
+// is one already on the way or nothing left to regrow.
+func (s *EventSourcer) scheduleVillageRegrowth(parent *event, village *city) error {
+	if _, ok := s.inMemoryState.regrowingVillages[village.id]; ok {
+		return nil
+	}
+	barbarians := s.cfg.World.Barbarians
+	regrows := false
+	for unitName, garrison := range barbarians.Garrison {
+		regrows = regrows || (barbarians.Regrowth[unitName] > 0 && village.unitCount[unitName] < garrison)
+	}
+	if !regrows {
+		return nil
+	}
+
+	payload, err := json.Marshal(&regrowVillageEvent{CityID: village.id})
+	if err != nil {
+		return err
+	}
+	s.chainEvents = append(s.chainEvents, &event{
+		id:      chainEventID(parent.id, regrowVillageEventName),
+		name:    regrowVillageEventName,
+		epoch:   parent.epoch + s.cfg.scaledDuration(barbarians.RegrowthInterval),
+		payload: string(payload),
+	})
+	s.inMemoryState.regrowingVillages[village.id] = struct{}{}
+	return nil
+}
+
+// The offered resources are taken from the city when the offer is created and
+// held by it, they take up as many of the merchants of the city until the offer
+// is accepted or cancelled.
+func (s *EventSourcer) processCreateTradeOfferEvent(_ context.Context, e *event) error {
+	// parsing
+	createTradeOffer := createTradeOfferEvent{}
+	err := json.Unmarshal([]byte(e.payload), &createTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	c, ok := s.inMemoryState.cityList[createTradeOffer.CityID]
+	if !ok || c.playerID != createTradeOffer.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
+	}
+	if _, ok := s.inMemoryState.tradeOfferList[createTradeOffer.TradeOfferID]; ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "unexpected repeated tradeOfferID")
+	}
+	offered, err := s.cfg.resourcesLoad(createTradeOffer.Offered)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	requested, err := s.cfg.resourcesLoad(createTradeOffer.Requested)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	if offered == 0 || requested == 0 {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "a trade goes both ways")
+	}
+	if s.inMemoryState.merchantsInUse(c.id)+offered > s.cfg.merchantCapacity(c.buildingsLevel) {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "not enough merchants")
+	}
+	err = s.configs.reCityCalculateResources(e.epoch, createTradeOffer.Offered, c)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.addTradeOffer(&tradeOffer{
+		id:           createTradeOffer.TradeOfferID,
+		playerID:     createTradeOffer.PlayerID,
+		cityID:       createTradeOffer.CityID,
+		offered:      createTradeOffer.Offered,
+		requested:    createTradeOffer.Requested,
+		createdEpoch: e.epoch,
+	})
+	s.toUpsert.offers[createTradeOffer.TradeOfferID] = struct{}{}
+	s.toUpsert.cities[createTradeOffer.CityID] = struct{}{}
+	return nil
+}
+
+// The accepting city pays the requested resources right away, then the
+// merchants of both cities set off with their side of the trade: the trade is
+// only done once both transports arrive.
+func (s *EventSourcer) processAcceptTradeOfferEvent(ctx context.Context, e *event) error {
+	// parsing
+	acceptTradeOffer := acceptTradeOfferEvent{}
+	err := json.Unmarshal([]byte(e.payload), &acceptTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	o, ok := s.inMemoryState.tradeOfferList[acceptTradeOffer.TradeOfferID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "the trade offer is no longer open")
+	}
+	if o.playerID == acceptTradeOffer.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot accept own trade offers")
+	}
+	buyerCity, ok := s.inMemoryState.cityList[acceptTradeOffer.CityID]
+	if !ok || buyerCity.playerID != acceptTradeOffer.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
+	}
+	requested, err := s.cfg.resourcesLoad(o.requested)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	if s.inMemoryState.merchantsInUse(buyerCity.id)+requested > s.cfg.merchantCapacity(buyerCity.buildingsLevel) {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "not enough merchants")
+	}
+	err = s.configs.reCityCalculateResources(e.epoch, o.requested, buyerCity)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	// the city of an open offer is still there, deleting it drops its offers
+	sellerCity := s.inMemoryState.cityList[o.cityID]
+
+	// insert chain events
+	// the transports are derived from the acceptance so that a re-sync starts the very same ones
+	err = s.startTransport(tMovementID(chainEventID(e.id, "offered")), o.playerID, sellerCity, buyerCity, o.offered, e.epoch)
+	if err != nil {
+		return err
+	}
+	err = s.startTransport(tMovementID(chainEventID(e.id, "requested")), acceptTradeOffer.PlayerID, buyerCity, sellerCity, o.requested, e.epoch)
+	if err != nil {
+		return err
+	}
+	s.sendSystemMail(
+		tMailID("trade-"+e.id),
+		e.epoch,
+		fmt.Sprintf("Trade offer accepted: %s", o.id),
+		fmt.Sprintf(
+			"The trade offer of %s was accepted by %s, the merchants of %s and %s are on their way.",
+			sellerCity.name, acceptTradeOffer.PlayerID, sellerCity.name, buyerCity.name,
+		),
+		o.playerID,
+	)
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.deleteTradeOffer(o.id)
+	s.toUpsert.offers[o.id] = struct{}{}
+	s.toUpsert.cities[buyerCity.id] = struct{}{}
+	return nil
+}
+
+// Cancelling a trade offer gives the held resources back to its city.
+func (s *EventSourcer) processCancelTradeOfferEvent(_ context.Context, e *event) error {
+	// parsing
+	cancelTradeOffer := cancelTradeOfferEvent{}
+	err := json.Unmarshal([]byte(e.payload), &cancelTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	o, ok := s.inMemoryState.tradeOfferList[cancelTradeOffer.TradeOfferID]
+	if !ok || o.playerID != cancelTradeOffer.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot cancel trade offers of other players")
+	}
+	c := s.inMemoryState.cityList[o.cityID]
+	for resourceName, resourceCount := range o.offered {
+		c.resourceBase[resourceName] += resourceCount
+	}
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.inMemoryState.deleteTradeOffer(o.id)
+	s.toUpsert.offers[o.id] = struct{}{}
+	s.toUpsert.cities[c.id] = struct{}{}
+	return nil
+}
+
+func (s *EventSourcer) processExchangeResourcesEvent(_ context.Context, e *event) error {
+	// parsing
+	exchangeResources := exchangeResourcesEvent{}
+	err := json.Unmarshal([]byte(e.payload), &exchangeResources)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	c, ok := s.inMemoryState.cityList[exchangeResources.CityID]
+	if !ok || c.playerID != exchangeResources.PlayerID {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "cannot alter cities of other players")
+	}
+	err = checkRequiredBuildings(s.cfg.Market.Exchange.RequiredBuildings, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	received, err := s.cfg.exchange(exchangeResources.From, exchangeResources.To, exchangeResources.Count)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	// NOTE: the resources are paid and received at once, so the received ones
+	// go on top of the base just like the ones of an arriving transport
+	err = s.configs.reCityCalculateResources(e.epoch, tResourcesCount{exchangeResources.From: exchangeResources.Count}, c)
+	if err != nil {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, err.Error())
+	}
+	c.resourceBase[exchangeResources.To] += received
+
+	// insert chain events
+
+	// upsert cached table and signal future view table upsert
+	s.toUpsert.cities[c.id] = struct{}{}
+	return nil
+}
+
+// The units of the player stationed in an allied city march back to the closest
+// city of the player.
+func (s *EventSourcer) processRecallReinforcementsEvent(_ context.Context, e *event) error {
+	// parsing
+	recallReinforcements := recallReinforcementsEvent{}
+	err := json.Unmarshal([]byte(e.payload), &recallReinforcements)
+	if err != nil {
+		return err
+	}
+
+	// validation and event calculations
+	c, ok := s.inMemoryState.cityList[recallReinforcements.CityID]
+	if !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "city does not exist")
+	}
+	if _, ok := c.reinforcements[recallReinforcements.PlayerID]; !ok {
+		return fmt.Errorf("%w, event %s, reason: %s", errPreConditionFailed, e.id, "no units of the player are stationed there")
+	}
+
+	// insert chain events
+	return s.sendReinforcementsHome(e, c, recallReinforcements.PlayerID)
+}
+
+// sendFormerAlliesHome sends back home the units a player that left an alliance
+// had stationed with its former allies, and theirs stationed with the player.
+func (s *EventSourcer) sendFormerAlliesHome(e *event, playerID tPlayerID) error {
+	for _, cityID := range sortedKeys(s.inMemoryState.reinforcedCities[playerID]) {
+		err := s.sendReinforcementsHome(e, s.inMemoryState.cityList[cityID], playerID)
+		if err != nil {
+			return err
+		}
+	}
+	for _, cityID := range sortedKeys(s.inMemoryState.citiesByPlayer[playerID]) {
+		c := s.inMemoryState.cityList[cityID]
+		for _, allyID := range sortedKeys(c.reinforcements) {
+			err := s.sendReinforcementsHome(e, c, allyID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sendReinforcementsHome takes the units of an ally out of a city on a return
+// movement to the closest city of the ally, they are lost if the ally has none.
+func (s *EventSourcer) sendReinforcementsHome(e *event, c *city, playerID tPlayerID) error {
+	unitCount := s.inMemoryState.withdrawReinforcements(c, playerID)
+	s.toUpsert.cities[c.id] = struct{}{}
+	s.toUpsert.players[playerID] = struct{}{}
+	home := s.inMemoryState.closestCity(playerID, c.locationX, c.locationY)
+	if home == nil {
+		return nil
+	}
+
+	movementID := tMovementID(chainEventID(e.id, tEventName("reinforcements/"+string(c.id)+"/"+string(playerID))))
+	speed := s.cfg.movementSpeed(troopsMovement, unitCount)
+	travelDurationSec := s.cfg.travelTime(c.locationX, c.locationY, home.locationX, home.locationY, speed)
+	returnMovement := &returnMovementEvent{
+		MovementID:    movementID,
+		PlayerID:      playerID,
+		OriginID:      c.id,
+		DestinationID: home.id,
+		DestinationX:  home.locationX,
+		DestinationY:  home.locationY,
+		UnitCount:     unitCount,
+		ResourceCount: make(tResourcesCount),
+	}
+	payload, err := json.Marshal(returnMovement)
+	if err != nil {
+		return err
+	}
+	s.chainEvents = append(s.chainEvents, &event{
+		id:      chainEventID(tEventID(movementID), returnMovementEventName),
+		name:    returnMovementEventName,
+		epoch:   e.epoch + travelDurationSec,
+		payload: string(payload),
+	})
+
+	s.inMemoryState.addMovement(&movement{
+		id:             movementID,
+		playerID:       playerID,
+		originID:       c.id,
+		destinationID:  home.id,
+		destinationX:   home.locationX,
+		destinationY:   home.locationY,
+		departureEpoch: e.epoch,
+		speed:          speed,
+		resourceCount:  make(tResourcesCount),
+		unitCount:      unitCount,
+		movementType:   troopsMovement,
+	})
+	s.toUpsert.movements[movementID] = struct{}{}
+	return nil
+}
+
+// startTransport sends resources that were already paid for from one city to
+// another, just like a transport started by a player.
+func (s *EventSourcer) startTransport(movementID tMovementID, playerID tPlayerID, origin, destination *city, resourceCount tResourcesCount, epoch tSec) error {
+	speed := s.cfg.Market.MerchantSpeed
+	travelDurationSec := s.cfg.travelTime(origin.locationX, origin.locationY, destination.locationX, destination.locationY, speed)
+
+	arrival := &arrivalMovementEvent{
+		MovementID:    movementID,
+		PlayerID:      playerID,
+		OriginID:      origin.id,
+		DestinationID: destination.id,
+		DestinationX:  destination.locationX,
+		DestinationY:  destination.locationY,
+		UnitCount:     make(tUnitsCount),
+		ResourceCount: resourceCount,
+		MovementType:  transportMovement,
+	}
+	payload, err := json.Marshal(arrival)
+	if err != nil {
+		return err
+	}
+	s.chainEvents = append(s.chainEvents, &event{
+		id:      chainEventID(tEventID(movementID), arrivalMovementEventName),
+		name:    arrivalMovementEventName,
+		epoch:   epoch + travelDurationSec,
+		payload: string(payload),
+	})
+
+	s.inMemoryState.addMovement(&movement{
+		id:             movementID,
+		playerID:       playerID,
+		originID:       origin.id,
+		destinationID:  destination.id,
+		destinationX:   destination.locationX,
+		destinationY:   destination.locationY,
+		departureEpoch: epoch,
+		speed:          speed,
+		resourceCount:  resourceCount,
+		unitCount:      make(tUnitsCount),
+		movementType:   transportMovement,
+	})
+	s.toUpsert.movements[movementID] = struct{}{}
+	return nil
+}
+
+// System generated mail has its ID derived from the event that generated it so
+// that re-processing the event on a re-sync does not deliver it twice. It is
+// delivered along with the views of the event.
+func (s *EventSourcer) sendSystemMail(id tMailID, epoch tSec, subject, content string, recipients ...tPlayerID) {
+	if _, ok := s.deliveredMails[id]; ok {
+		return
+	}
+	// nobody reads the mail of the barbarians
+	recipients = slices.DeleteFunc(recipients, func(recipient tPlayerID) bool { return recipient == barbarianPlayerID })
+	s.mails = append(s.mails, &dbMailDelivery{
+		mail: &dbMail{
+			id:        id,
+			subject:   subject,
+			content:   content,
+			sentEpoch: epoch,
+		},
+		recipients: recipients,
+	})
+}
+
+// Notifies the player that issued the event that it could not be carried out.
+func (s *EventSourcer) reportRejectedEvent(e *event, reason error) {
+	issuer := struct {
+		PlayerID tPlayerID `json:"playerID"`
+	}{}
+	err := json.Unmarshal([]byte(e.payload), &issuer)
+	if err != nil || issuer.PlayerID == "" {
+		return
+	}
+	s.sendSystemMail(
+		tMailID("rejected-"+e.id),
+		e.epoch,
+		fmt.Sprintf("Command rejected: %s", e.name),
+		reason.Error(),
+		issuer.PlayerID,
+	)
+}
+
+// reCityCalculateResources moves the resource base of the city forward to the
+// epoch and pays the cost out of it. The resources accrued since the last time
+// are produced at the rates of each config version in effect meanwhile, so that
+// a rebalance only applies from the epoch it became effective on.
+func (h *ConfigHistory) reCityCalculateResources(epoch tSec, cost tResourcesCount, c *city) error {
+	return reCityCalculateResources(h.until(epoch), epoch, cost, c)
+}
+
+// The versions are the ones in effect up to the epoch, the last one of them
+// decides which resources there are.
+func reCityCalculateResources(versions []*Config, epoch tSec, cost tResourcesCount, c *city) error {
+	// TODO: measure and optimize
+	accrued := make(map[tResourceName]float64)
+	for i, cfg := range versions {
+		from, to := c.resourceEpoch, epoch
+		if i > 0 {
+			from = max(from, cfg.effectiveFrom)
+		}
+		if i+1 < len(versions) {
+			to = min(to, versions[i+1].effectiveFrom)
+		}
+		if to <= from {
 			continue
 		}
-		// TODO: measure and optimize
-		multiplier := 1.0
-		for _, buildingKey := range cumulativeResourceMultipliers[tResourceName(resourceName)] {
-			// TODO: formalize these equations to calculate game time
-			multiplier *= cfg.Buildings[buildingKey].ResourceMultiplier[c.buildingsLevel[buildingKey]]
+		for resourceName, trickle := range cfg.ResourceTrickles {
+			multiplier := 1.0
+			for _, buildingKey := range cfg.cumulativeResourceMultipliers[resourceName] {
+				// TODO: formalize these equations to calculate game time
+				multiplier *= cfg.Buildings[buildingKey].ResourceMultiplier[c.buildingsLevel[buildingKey]]
+			}
+			multiplier *= cfg.resourceFieldMultiplier(resourceName, c.locationX, c.locationY)
+			accrued[resourceName] += float64(to-from) * float64(trickle) * multiplier * cfg.WorldSpeed
 		}
-		currentResources := tResourceCount(float64(c.resourceBase[resourceName]) +
-			float64(epoch-c.resourceEpoch)*
-				float64(cfg.ResourceTrickles[tResourceName(resourceName)])*multiplier)
-		if currentResources > resourceCost {
+	}
+	cfg := versions[len(versions)-1]
+	currentResources := make(tResourcesCount, len(cfg.ResourceTrickles))
+	for resourceName := range cfg.ResourceTrickles {
+		currentResources[resourceName] = tResourceCount(float64(c.resourceBase[resourceName]) + accrued[resourceName])
+	}
+
+	missingResources := ""
+	for resourceName, resourceCost := range cost {
+		if currentResources[resourceName] >= resourceCost {
 			continue
 		}
 		missingResources += fmt.Sprintf("missing %s resources", resourceName)
@@ -1000,23 +2253,16 @@ func reCityCalculateResources(epoch tSec, cost tResourcesCount, c *city) error {
 		return fmt.Errorf(missingResources)
 	}
 
-	c.resourceEpoch = epoch
-	for resourceName := range c.resourceBase {
-		multiplier := 1.0
-		for _, buildingKey := range cumulativeResourceMultipliers[tResourceName(resourceName)] {
-			// TODO: formalize these equations to calculate game time
-			multiplier *= cfg.Buildings[buildingKey].ResourceMultiplier[c.buildingsLevel[buildingKey]]
-		}
-		currentResources := tResourceCount(float64(c.resourceBase[resourceName]) +
-			float64(epoch-c.resourceEpoch)*
-				float64(cfg.ResourceTrickles[tResourceName(resourceName)])*multiplier)
-		c.resourceBase[resourceName] = currentResources - cost[resourceName]
+	// NOTE: the base is only moved forward once the accrued resources are counted
+	for resourceName, resourceCount := range currentResources {
+		c.resourceBase[resourceName] = resourceCount - cost[resourceName]
 	}
+	c.resourceEpoch = epoch
 	return nil
 }
 
-func getGroupMovementSpeed(unitCount tUnitsCount) tSpeed {
-	for _, unitName := range sortedSlowestUnits {
+func (cfg *Config) getGroupMovementSpeed(unitCount tUnitsCount) tSpeed {
+	for _, unitName := range cfg.sortedSlowestUnits {
 		if unitCount[unitName] > 0 {
 			return cfg.Units[unitName].UnitSpeed
 		}
@@ -1025,12 +2271,73 @@ func getGroupMovementSpeed(unitCount tUnitsCount) tSpeed {
 	return 1.0
 }
 
-func travelTime(x1, y1, x2, y2 tCoordinate, speed tSpeed) tSec {
+// Transports go at the speed of the merchants, troops at the speed of their
+// slowest unit.
+func (cfg *Config) movementSpeed(movementType tMovementType, unitCount tUnitsCount) tSpeed {
+	if movementType == transportMovement {
+		return cfg.Market.MerchantSpeed
+	}
+	return cfg.getGroupMovementSpeed(unitCount)
+}
+
+// resourcesLoad adds up resources to be carried around, which must all be known
+// and not negative.
+func (cfg *Config) resourcesLoad(resourceCount tResourcesCount) (tResourceCount, error) {
+	var load tResourceCount
+	for _, resourceName := range sortedKeys(resourceCount) {
+		if _, ok := cfg.ResourceTrickles[resourceName]; !ok {
+			return 0, fmt.Errorf("unknown %s resources", resourceName)
+		}
+		if resourceCount[resourceName] < 0 {
+			return 0, fmt.Errorf("negative %s resources", resourceName)
+		}
+		load += resourceCount[resourceName]
+	}
+	return load, nil
+}
+
+func (cfg *Config) travelTime(x1, y1, x2, y2 tCoordinate, speed tSpeed) tSec {
 	// TODO: measure and optimize
 	squareDist := (x1-x2)*(x1-x2) + (y1-y2)*(y1-y2)
 	if squareDist <= 0 {
 		return 0.0
 	}
 
-	return tSec(math.Sqrt(float64(squareDist)) / float64(speed))
+	// NOTE: the terrain along the way slows down (or speeds up) the whole trip
+	distance := math.Sqrt(float64(squareDist))
+	return cfg.scaledDuration(tSec(distance * cfg.pathCost(x1, y1, x2, y2, distance) / float64(speed)))
+}
+
+// eventRand is the source of randomness of an event outcome, seeded by its ID
+// so that a re-sync replays the same outcome.
+func eventRand(id tEventID) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// chainEventID derives the ID of the event that follows from a parent event,
+// so that a re-sync re-processing the parent inserts the very same event
+// instead of a duplicate.
+func chainEventID(parentID tEventID, name tEventName) tEventID {
+	return tEventID(uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(parentID)+"/"+string(name))).String())
+}
+
+// sortedKeys allows to iterate a map in a deterministic order.
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func formatUnitsCount(unitCount tUnitsCount) string {
+	unitNames := sortedKeys(unitCount)
+	formatted := make([]string, len(unitNames))
+	for i, unitName := range unitNames {
+		formatted[i] = fmt.Sprintf("%s: %d", unitName, unitCount[unitName])
+	}
+	return strings.Join(formatted, ", ")
 }
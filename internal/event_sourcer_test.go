@@ -1 +1,1052 @@
 package internal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestEventSourcer(t *testing.T) (*EventSourcer, *inserterService, *inMemoryRepository, *FakeClock) {
+	t.Helper()
+	return newTestEventSourcerFrom(t, "../config.json")
+}
+
+func newTestEventSourcerFrom(t *testing.T, configPath string) (*EventSourcer, *inserterService, *inMemoryRepository, *FakeClock) {
+	t.Helper()
+	repository := newInMemoryRepository()
+	clock := NewFakeClock(time.Unix(1_000_000, 0))
+	configs, err := NewConfigHistory(context.Background(), repository, clock, configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventSourcer := NewEventSourcer(repository, configs, clock)
+	inserter := &inserterService{repository: repository, eventSourcer: eventSourcer, configs: configs, clock: clock}
+	return eventSourcer, inserter, repository, clock
+}
+
+// processDueEvents processes the events due that were not processed yet, one
+// at a time, as processing may insert chain events that are already due.
+// Rejected events are fine, e.g., the ones already processed as they were
+// queued.
+func processDueEvents(t *testing.T, s *EventSourcer, repository *inMemoryRepository, clock *FakeClock, processed map[tEventID]struct{}) {
+	t.Helper()
+	ctx := context.Background()
+	for {
+		events, err := repository.ListEvents(ctx, clock.Now().Unix())
+		mustNoErr(t, err)
+		var next *event
+		for _, e := range events {
+			if _, ok := processed[e.id]; !ok {
+				next = e
+				break
+			}
+		}
+		if next == nil {
+			return
+		}
+		processed[next.id] = struct{}{}
+		err = s.processEvent(ctx, next)
+		if err != nil && !errors.Is(err, errPreConditionFailed) {
+			t.Fatal(err)
+		}
+	}
+}
+
+// processQueued processes the event an inserter just queued.
+func processQueued(t *testing.T, s *EventSourcer) {
+	t.Helper()
+	err := s.processEvent(context.Background(), <-s.internalEventQueue)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventSourcerUpgradeBuilding(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	dbc, err := repository.GetCity(ctx, "c1", "p1")
+	mustNoErr(t, err)
+	mustEqual(t, dbc.resourceEpoch, tSec(1_000_000))
+
+	clock.Advance(200 * time.Second)
+	mustNoErr(t, inserter.QueueBuilding(ctx, "p1", &buildingQueueItem{id: "b1", cityID: "c1", targetLevel: 1, targetBuilding: "mines"}))
+	processQueued(t, eventSourcer)
+	items, err := repository.ListBuildingQueueItems(ctx, "c1", "p1", "", 10)
+	mustNoErr(t, err)
+	mustEqual(t, len(items), 1)
+
+	// the upgrade is a chain event, only processed on re-sync once it is due
+	clock.Advance(10 * time.Second)
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	dbc, err = repository.GetCity(ctx, "c1", "p1")
+	mustNoErr(t, err)
+	c, err := cityFromDBModel(dbc)
+	mustNoErr(t, err)
+	mustEqual(t, c.buildingsLevel["mines"], tBuildingLevel(1))
+	items, err = repository.ListBuildingQueueItems(ctx, "c1", "p1", "", 10)
+	mustNoErr(t, err)
+	mustEqual(t, len(items), 0)
+}
+
+func TestEventSourcerReSyncKeepsChainEventIDs(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	clock.Advance(200 * time.Second)
+	mustNoErr(t, inserter.QueueBuilding(ctx, "p1", &buildingQueueItem{id: "b1", cityID: "c1", targetLevel: 1, targetBuilding: "mines"}))
+	processQueued(t, eventSourcer)
+	events, err := repository.ListEvents(ctx, clock.Now().Add(time.Hour).Unix())
+	mustNoErr(t, err)
+	mustEqual(t, len(events), 3)
+
+	// re-processing the queued building yields the very same upgrade event
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	replayed, err := repository.ListEvents(ctx, clock.Now().Add(time.Hour).Unix())
+	mustNoErr(t, err)
+	mustEqual(t, replayed, events)
+	mustEqual(t, events[2].id, chainEventID(events[1].id, upgradeBuildingEventName))
+}
+
+// failingViewsRepository fails to write views while failing is set.
+type failingViewsRepository struct {
+	*inMemoryRepository
+	failing bool
+}
+
+func (r *failingViewsRepository) UpsertViews(ctx context.Context, v *dbViews) error {
+	if r.failing {
+		return errors.New("database is gone")
+	}
+	return r.inMemoryRepository.UpsertViews(ctx, v)
+}
+
+func TestEventSourcerRetriesFailedViews(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, _ := newTestEventSourcer(t)
+	failing := &failingViewsRepository{inMemoryRepository: repository, failing: true}
+	eventSourcer.repository = failing
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	err := eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+	if err == nil {
+		t.Fatal("expected the views upsert to fail")
+	}
+	_, err = repository.GetCity(ctx, "c1", "p1")
+	mustNoRows(t, err)
+
+	// the city is still pending and written along with the next event
+	failing.failing = false
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c2", name: "two", locationX: 3, locationY: 3}))
+	processQueued(t, eventSourcer)
+	_, err = repository.GetCity(ctx, "c1", "p1")
+	mustNoErr(t, err)
+	_, err = repository.GetCity(ctx, "c2", "p2")
+	mustNoErr(t, err)
+}
+
+func TestEventSourcerScoresWithTheConfigOfTheEvent(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	eventSourcer.inMemoryState.cityList["c1"].unitCount["stickmen"] = 10
+	v1 := eventSourcer.configs.Latest()
+
+	// the movement is sent before the stickmen get stronger, and only processed after
+	mustNoErr(t, inserter.StartMovement(ctx, "p1", &movement{id: "m1", originID: "c1", destinationX: 5, destinationY: 5, unitCount: tUnitsCount{"stickmen": 1}, resourceCount: make(tResourcesCount)}))
+	clock.Advance(time.Second)
+	v2, err := eventSourcer.configs.Activate(ctx, rebalancedConfig(t, func(c map[string]any) {
+		c["units"].(map[string]any)["stickmen"].(map[string]any)["stats"] = map[string]any{"pierce": 20, "slash": 20}
+	}))
+	mustNoErr(t, err)
+	processQueued(t, eventSourcer)
+	p, err := repository.GetPlayer(ctx, "p1")
+	mustNoErr(t, err)
+	mustEqual(t, p.score, eventSourcer.inMemoryState.playerScore(v1, "p1"))
+	if p.score == eventSourcer.inMemoryState.playerScore(v2, "p1") {
+		t.Fatal("expected the rebalance to change the score")
+	}
+}
+
+// recordingViewsRepository keeps every batch of views written.
+type recordingViewsRepository struct {
+	*inMemoryRepository
+	views []*dbViews
+}
+
+func (r *recordingViewsRepository) UpsertViews(ctx context.Context, v *dbViews) error {
+	r.views = append(r.views, v)
+	return r.inMemoryRepository.UpsertViews(ctx, v)
+}
+
+func TestEventSourcerReportsRejectedEventsOnce(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	// events of the same epoch have no set order, the replay must reject the same one
+	clock.Advance(time.Second)
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c2", name: "two", locationX: 1, locationY: 1}))
+	rejected := <-eventSourcer.internalEventQueue
+	err := eventSourcer.processEvent(ctx, rejected)
+	if !errors.Is(err, errPreConditionFailed) {
+		t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+	}
+	_, err = repository.GetMail(ctx, "rejected-"+string(rejected.id), "p2")
+	mustNoErr(t, err)
+
+	// replaying the rejected event does not deliver the report again
+	recording := &recordingViewsRepository{inMemoryRepository: repository}
+	eventSourcer.repository = recording
+	mustNoErr(t, eventSourcer.reSyncEvents(ctx))
+	mustEqual(t, len(recording.views), 1)
+	mustEqual(t, len(recording.views[0].mails), 0)
+}
+
+func TestEventSourcerResearch(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+	state := eventSourcer.inMemoryState
+	processed := make(map[tEventID]struct{})
+	cfg := eventSourcer.configs.Latest()
+	mustPreConditionFail := func() {
+		t.Helper()
+		err := eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+		if !errors.Is(err, errPreConditionFailed) {
+			t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+		}
+	}
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	c := state.cityList["c1"]
+	c.buildingsLevel["barracks"] = 2
+	c.resourceBase = tResourcesCount{"sticks": 10_000, "circles": 10_000}
+	c.resourceEpoch = tSec(clock.Now().Unix())
+	eventSourcer.toUpsert.cities[c.id] = struct{}{}
+	mustNoErr(t, eventSourcer.upsertViews(ctx))
+
+	// swordsmen are locked until swordsmanship is researched, which is required by sharp sticks
+	mustNoErr(t, inserter.QueueUnit(ctx, "p1", &unitQueueItem{id: "u1", cityID: "c1", unitCount: 1, unitType: "swordsmen"}))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.QueueResearch(ctx, "p1", &researchQueueItem{id: "r1", cityID: "c1", researchName: "sharpsticks"}))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.QueueResearch(ctx, "p1", &researchQueueItem{id: "r2", cityID: "c1", researchName: "swordsmanship"}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.hasResearched("p1", "swordsmanship"), false)
+	// the research is done once per player, even if still ongoing
+	mustNoErr(t, inserter.QueueResearch(ctx, "p1", &researchQueueItem{id: "r3", cityID: "c1", researchName: "swordsmanship"}))
+	mustPreConditionFail()
+	clock.Advance(time.Duration(cfg.Research["swordsmanship"].DurationSec) * time.Second)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.hasResearched("p1", "swordsmanship"), true)
+	mustNoErr(t, inserter.QueueUnit(ctx, "p1", &unitQueueItem{id: "u2", cityID: "c1", unitCount: 1, unitType: "swordsmen"}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, len(state.unitQueuesPerCity["c1"]), 1)
+
+	// the stat multipliers of research apply to every unit of the player
+	mustEqual(t, state.statMultiplier(cfg, "p1", "pierce"), 1.0)
+	mustNoErr(t, inserter.QueueResearch(ctx, "p1", &researchQueueItem{id: "r4", cityID: "c1", researchName: "sharpsticks"}))
+	processQueued(t, eventSourcer)
+	clock.Advance(time.Duration(cfg.Research["sharpsticks"].DurationSec) * time.Second)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.statMultiplier(cfg, "p1", "pierce"), 1.1)
+	mustEqual(t, state.statMultiplier(cfg, "p1", "slash"), 1.0)
+	mustEqual(t, state.statMultiplier(cfg, "p2", "pierce"), 1.0)
+}
+
+func TestEventSourcerBarbarianVillages(t *testing.T) {
+	ctx := context.Background()
+	configPath := writeTestWorldConfig(t, worldSpecs{
+		Seed:        1,
+		Size:        10,
+		FeatureSize: 1,
+		Barbarians: barbarianSpecs{
+			Frequency:        0.05,
+			Garrison:         tUnitsCount{"stickmen": 10},
+			Regrowth:         tUnitsCount{"stickmen": 2},
+			RegrowthInterval: 60,
+			Conquerable:      true,
+		},
+	})
+	eventSourcer, inserter, repository, clock := newTestEventSourcerFrom(t, configPath)
+	state := eventSourcer.inMemoryState
+	villages := eventSourcer.configs.Latest().villages
+	if len(villages) < 3 {
+		t.Fatalf("expected a few villages, got %d", len(villages))
+	}
+	processed := make(map[tEventID]struct{})
+	processDue := func() {
+		t.Helper()
+		processDueEvents(t, eventSourcer, repository, clock, processed)
+	}
+	attack := func(village coordinates, stickmen tUnitCount) {
+		t.Helper()
+		mustNoErr(t, inserter.StartMovement(ctx, "p1", &movement{
+			id:            tMovementID(fmt.Sprintf("m%d,%d", village.x, village.y)),
+			originID:      "c1",
+			destinationID: villageCityID(village.x, village.y),
+			destinationX:  village.x,
+			destinationY:  village.y,
+			unitCount:     tUnitsCount{"stickmen": stickmen},
+			resourceCount: make(tResourcesCount),
+		}))
+		<-eventSourcer.internalEventQueue
+		processDue()
+		origin := state.cityList["c1"]
+		clock.Advance(time.Duration(eventSourcer.configs.Latest().travelTime(origin.locationX, origin.locationY, village.x, village.y, 1)) * time.Second)
+		processDue()
+	}
+
+	// the villages are there as soon as the world has any event, their
+	// locations are taken
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: villages[2].x, locationY: villages[2].y}))
+	err := eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+	if !errors.Is(err, errPreConditionFailed) {
+		t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+	}
+	free := coordinates{}
+	for state.getCityByLocation(free.x, free.y) != nil {
+		free.x++
+	}
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: free.x, locationY: free.y}))
+	processQueued(t, eventSourcer)
+	state.cityList["c1"].unitCount["stickmen"] = 100
+	plundered := state.cityList[villageCityID(villages[0].x, villages[0].y)]
+	mustEqual(t, plundered.playerID, barbarianPlayerID)
+	mustEqual(t, plundered.unitCount, tUnitsCount{"stickmen": 10})
+
+	// twice the garrison wins, but cannot wipe it out
+	attack(villages[0], 20)
+	survivors := plundered.unitCount["stickmen"]
+	if survivors == 0 || survivors >= 10 {
+		t.Fatalf("expected the village to lose part of its garrison, it has %d stickmen", survivors)
+	}
+	mustEqual(t, plundered.playerID, barbarianPlayerID)
+	clock.Advance(60 * time.Second)
+	processDue()
+	mustEqual(t, plundered.unitCount["stickmen"], min(10, survivors+2))
+
+	// an empty village is taken over
+	conquered := state.cityList[villageCityID(villages[1].x, villages[1].y)]
+	conquered.unitCount["stickmen"] = 0
+	attack(villages[1], 5)
+	mustEqual(t, conquered.playerID, tPlayerID("p1"))
+	mustEqual(t, conquered.unitCount, tUnitsCount{"stickmen": 5})
+	dbc, err := repository.GetCity(ctx, string(conquered.id), "p1")
+	mustNoErr(t, err)
+	mustEqual(t, dbc.playerID, tPlayerID("p1"))
+}
+
+func TestEventSourcerBeginnerProtection(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+	state := eventSourcer.inMemoryState
+	processed := make(map[tEventID]struct{})
+	protection := eventSourcer.configs.Latest().BeginnerProtection
+	start := tSec(clock.Now().Unix())
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c2", name: "two", locationX: 3, locationY: 3}))
+	processQueued(t, eventSourcer)
+	// later cities share the protection of the first one
+	clock.Advance(100 * time.Second)
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c3", name: "three", locationX: 5, locationY: 5}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c1"].protectedUntil, start+protection)
+	mustEqual(t, state.cityList["c3"].protectedUntil, start+protection)
+	state.cityList["c1"].unitCount["stickmen"] = 10
+	state.cityList["c2"].unitCount["stickmen"] = 10
+
+	// the attack bounces off the protected city, and the attacker is no longer protected
+	mustNoErr(t, inserter.StartMovement(ctx, "p2", &movement{id: "m1", originID: "c2", destinationID: "c1", destinationX: 1, destinationY: 1, unitCount: tUnitsCount{"stickmen": 5}, resourceCount: make(tResourcesCount)}))
+	processQueued(t, eventSourcer)
+	attackedAt := tSec(clock.Now().Unix())
+	mustEqual(t, state.cityList["c2"].protectedUntil, attackedAt)
+	travelTime := time.Duration(eventSourcer.configs.Latest().travelTime(3, 3, 1, 1, 1)) * time.Second
+	clock.Advance(travelTime)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c1"].unitCount["stickmen"], tUnitCount(10))
+	mustEqual(t, state.movementList["m1"].destinationID, tCityID("c2"))
+	// the way back is timed from the bounce
+	mustEqual(t, state.movementList["m1"].departureEpoch, tSec(clock.Now().Unix()))
+	mustEqual(t, state.movementList["m1"].speed, eventSourcer.configs.Latest().Units["stickmen"].UnitSpeed)
+	arrivals, err := repository.ListEvents(ctx, clock.Now().Unix())
+	mustNoErr(t, err)
+	for _, e := range arrivals {
+		if e.name != arrivalMovementEventName {
+			continue
+		}
+		for _, playerID := range []string{"p1", "p2"} {
+			_, err = repository.GetMail(ctx, "protection-"+string(e.id), playerID)
+			mustNoErr(t, err)
+		}
+	}
+	clock.Advance(travelTime)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c2"].unitCount["stickmen"], tUnitCount(10))
+	_, ok := state.movementList["m1"]
+	mustEqual(t, ok, false)
+
+	// the protection of every city is over once its owner attacks
+	mustNoErr(t, inserter.StartMovement(ctx, "p1", &movement{id: "m2", originID: "c3", destinationID: "c2", destinationX: 3, destinationY: 3, unitCount: tUnitsCount{}, resourceCount: make(tResourcesCount)}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c1"].protectedUntil, tSec(clock.Now().Unix()))
+	mustEqual(t, state.cityList["c3"].protectedUntil, tSec(clock.Now().Unix()))
+
+	// losing every city does not earn a new protection
+	mustNoErr(t, inserter.DeleteCity(ctx, "p2", "c2"))
+	processQueued(t, eventSourcer)
+	clock.Advance(100 * time.Second)
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c4", name: "four", locationX: 7, locationY: 7}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c4"].protectedUntil, attackedAt)
+	settledAt := tSec(clock.Now().Unix())
+	mustNoErr(t, inserter.CreateCity(ctx, "p3", &city{id: "c5", name: "five", locationX: 9, locationY: 9}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.DeleteCity(ctx, "p3", "c5"))
+	processQueued(t, eventSourcer)
+	clock.Advance(100 * time.Second)
+	mustNoErr(t, inserter.CreateCity(ctx, "p3", &city{id: "c6", name: "six", locationX: 9, locationY: 9}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c6"].protectedUntil, settledAt+protection)
+}
+
+func TestEventSourcerAlliances(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, _ := newTestEventSourcer(t)
+	state := eventSourcer.inMemoryState
+	viewer := &viewerService{repository: repository}
+	mustPreConditionFail := func() {
+		t.Helper()
+		err := eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+		if !errors.Is(err, errPreConditionFailed) {
+			t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+		}
+	}
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c2", name: "two", locationX: 3, locationY: 3}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.CreateAlliance(ctx, "p1", &alliance{id: "a1", name: "allies"}))
+	processQueued(t, eventSourcer)
+
+	// only the invited join, and only the leader invites
+	mustNoErr(t, inserter.JoinAlliance(ctx, "p2", "a1"))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.InviteToAlliance(ctx, "p2", "a1", "p2"))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.InviteToAlliance(ctx, "p1", "a1", "p2"))
+	processQueued(t, eventSourcer)
+	_, err := viewer.GetCity(ctx, "c1", "p2")
+	mustNoRows(t, err)
+	mustNoErr(t, inserter.JoinAlliance(ctx, "p2", "a1"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.areAllies("p1", "p2"), true)
+	a, err := viewer.GetAlliance(ctx, "a1")
+	mustNoErr(t, err)
+	mustEqual(t, a.members, map[tPlayerID]struct{}{"p1": {}, "p2": {}})
+	mustEqual(t, len(a.invites), 0)
+
+	// allies read each other's cities in full
+	c, err := viewer.GetCity(ctx, "c1", "p2")
+	mustNoErr(t, err)
+	mustEqual(t, c.playerID, tPlayerID("p1"))
+	_, err = viewer.GetCity(ctx, "c2", "p1")
+	mustNoErr(t, err)
+
+	// and no longer once they leave
+	mustNoErr(t, inserter.LeaveAlliance(ctx, "p2", "a1"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.areAllies("p1", "p2"), false)
+	_, err = viewer.GetCity(ctx, "c1", "p2")
+	mustNoRows(t, err)
+	mustNoErr(t, inserter.LeaveAlliance(ctx, "p2", "a1"))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.JoinAlliance(ctx, "p2", "a1"))
+	mustPreConditionFail()
+}
+
+func TestEventSourcerAlliedReinforcements(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+	state := eventSourcer.inMemoryState
+	processed := make(map[tEventID]struct{})
+	cfg := eventSourcer.configs.Latest()
+	travelTime := func(x1, y1, x2, y2 tCoordinate) time.Duration {
+		return time.Duration(cfg.travelTime(x1, y1, x2, y2, cfg.Units["stickmen"].UnitSpeed)) * time.Second
+	}
+
+	for i, playerID := range []string{"p1", "p2", "p3"} {
+		cityID := tCityID(fmt.Sprintf("c%d", i+1))
+		location := tCoordinate(2*i + 1)
+		mustNoErr(t, inserter.CreateCity(ctx, playerID, &city{id: cityID, name: string(cityID), locationX: location, locationY: location}))
+		processQueued(t, eventSourcer)
+		state.cityList[cityID].protectedUntil = 0
+	}
+	mustNoErr(t, inserter.CreateAlliance(ctx, "p1", &alliance{id: "a1", name: "allies"}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.InviteToAlliance(ctx, "p1", "a1", "p2"))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.JoinAlliance(ctx, "p2", "a1"))
+	processQueued(t, eventSourcer)
+	state.cityList["c2"].unitCount["stickmen"] = 11
+	state.cityList["c3"].unitCount["stickmen"] = 6
+	p1Score, p2Score := state.playerScore(cfg, "p1"), state.playerScore(cfg, "p2")
+
+	// the units of an ally are stationed in the city, and still count for the ally
+	mustNoErr(t, inserter.StartMovement(ctx, "p2", &movement{id: "m1", originID: "c2", destinationID: "c1", destinationX: 1, destinationY: 1, unitCount: tUnitsCount{"stickmen": 10}, resourceCount: make(tResourcesCount)}))
+	processQueued(t, eventSourcer)
+	clock.Advance(travelTime(3, 3, 1, 1))
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c1"].unitCount["stickmen"], tUnitCount(0))
+	mustEqual(t, state.cityList["c1"].reinforcements, map[tPlayerID]tUnitsCount{"p2": {"stickmen": 10}})
+	mustEqual(t, state.playerScore(cfg, "p1"), p1Score)
+	mustEqual(t, state.playerScore(cfg, "p2"), p2Score)
+	dbc, err := repository.GetCity(ctx, "c1", "p2")
+	mustNoErr(t, err)
+	c, err := cityFromDBModel(dbc)
+	mustNoErr(t, err)
+	mustEqual(t, c.reinforcements, map[tPlayerID]tUnitsCount{"p2": {"stickmen": 10}})
+
+	// and they defend it
+	mustNoErr(t, inserter.StartMovement(ctx, "p3", &movement{id: "m2", originID: "c3", destinationID: "c1", destinationX: 1, destinationY: 1, unitCount: tUnitsCount{"stickmen": 5}, resourceCount: make(tResourcesCount)}))
+	processQueued(t, eventSourcer)
+	clock.Advance(travelTime(5, 5, 1, 1))
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	if state.defencePoints["p2"] <= 0 || state.defencePoints["p1"] != 0 {
+		t.Fatalf("expected the defence points to go to the ally, got %v", state.defencePoints)
+	}
+	if state.cityList["c1"].reinforcements["p2"]["stickmen"] > 10 || state.attackPoints["p3"] != cfg.unitsPower(tUnitsCount{"stickmen": 10})-cfg.unitsPower(state.cityList["c1"].reinforcements["p2"]) {
+		t.Fatalf("expected the attack points to be the stationed units lost, got %v", state.attackPoints)
+	}
+
+	// an ally sees the city but does not command it
+	err = inserter.QueueUnit(ctx, "p2", &unitQueueItem{id: "q1", cityID: "c1", unitCount: 1, unitType: "stickmen"})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got %v, want %v", err, sql.ErrNoRows)
+	}
+
+	// recalled units march back to the closest city of the ally
+	state.cityList["c1"].reinforcements["p2"]["stickmen"] = 4
+	state.cityList["c2"].unitCount["stickmen"] = 1
+	mustNoErr(t, inserter.RecallReinforcements(ctx, "p2", "c1"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, len(state.cityList["c1"].reinforcements), 0)
+	clock.Advance(travelTime(1, 1, 3, 3))
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c2"].unitCount["stickmen"], tUnitCount(5))
+	mustEqual(t, len(state.movementsByPlayer["p2"]), 0)
+	mustNoErr(t, inserter.RecallReinforcements(ctx, "p2", "c1"))
+	err = eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+	if !errors.Is(err, errPreConditionFailed) {
+		t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+	}
+
+	// and so do the units of a player that leaves the alliance
+	mustNoErr(t, inserter.StartMovement(ctx, "p2", &movement{id: "m3", originID: "c2", destinationID: "c1", destinationX: 1, destinationY: 1, unitCount: tUnitsCount{"stickmen": 4}, resourceCount: make(tResourcesCount)}))
+	processQueued(t, eventSourcer)
+	clock.Advance(travelTime(3, 3, 1, 1))
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c1"].reinforcements, map[tPlayerID]tUnitsCount{"p2": {"stickmen": 4}})
+	mustNoErr(t, inserter.LeaveAlliance(ctx, "p2", "a1"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, len(state.cityList["c1"].reinforcements), 0)
+	clock.Advance(travelTime(1, 1, 3, 3))
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c2"].unitCount["stickmen"], tUnitCount(5))
+	mustEqual(t, len(state.reinforcedCities), 0)
+}
+
+func TestEventSourcerMarket(t *testing.T) {
+	ctx := context.Background()
+	eventSourcer, inserter, repository, clock := newTestEventSourcer(t)
+	state := eventSourcer.inMemoryState
+	processed := make(map[tEventID]struct{})
+	now := func() tSec { return tSec(clock.Now().Unix()) }
+	mustPreConditionFail := func() {
+		t.Helper()
+		err := eventSourcer.processEvent(ctx, <-eventSourcer.internalEventQueue)
+		if !errors.Is(err, errPreConditionFailed) {
+			t.Fatalf("got %v, want %v", err, errPreConditionFailed)
+		}
+	}
+
+	mustNoErr(t, inserter.CreateCity(ctx, "p1", &city{id: "c1", name: "one", locationX: 1, locationY: 1}))
+	processQueued(t, eventSourcer)
+	mustNoErr(t, inserter.CreateCity(ctx, "p2", &city{id: "c2", name: "two", locationX: 3, locationY: 3}))
+	processQueued(t, eventSourcer)
+
+	// a city without a marketplace has no merchants to send
+	err := inserter.CreateTradeOffer(ctx, "p1", &tradeOffer{id: "o1", cityID: "c1", offered: tResourcesCount{"sticks": 100}, requested: tResourcesCount{"circles": 100}})
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	for _, c := range []*city{state.cityList["c1"], state.cityList["c2"]} {
+		c.buildingsLevel["marketplace"] = 1
+		c.resourceBase = tResourcesCount{"sticks": 1000, "circles": 1000}
+		c.resourceEpoch = now()
+		eventSourcer.toUpsert.cities[c.id] = struct{}{}
+	}
+	mustNoErr(t, eventSourcer.upsertViews(ctx))
+	protectedUntil := state.cityList["c1"].protectedUntil
+	travelTime := time.Duration(eventSourcer.configs.Latest().travelTime(1, 1, 3, 3, eventSourcer.configs.Latest().Market.MerchantSpeed)) * time.Second
+
+	// merchants carry up to the capacity of the marketplace, and no units
+	mustNoErr(t, inserter.StartMovement(ctx, "p1", &movement{id: "m1", originID: "c1", destinationID: "c2", destinationX: 3, destinationY: 3, unitCount: tUnitsCount{}, resourceCount: tResourcesCount{"sticks": 600}, movementType: transportMovement}))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.StartMovement(ctx, "p1", &movement{id: "m1", originID: "c1", destinationID: "c2", destinationX: 3, destinationY: 3, unitCount: tUnitsCount{}, resourceCount: tResourcesCount{"sticks": 100}, movementType: transportMovement}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c1"].resourceBase["sticks"], tResourceCount(900))
+	mustEqual(t, state.cityList["c1"].protectedUntil, protectedUntil)
+	mustEqual(t, state.merchantsInUse("c1"), tResourceCount(100))
+	clock.Advance(travelTime)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, state.cityList["c2"].resourceBase["sticks"], tResourceCount(1100))
+	_, ok := state.movementList["m1"]
+	mustEqual(t, ok, false)
+
+	// an open offer holds both the resources and the merchants
+	for _, c := range []*city{state.cityList["c1"], state.cityList["c2"]} {
+		c.resourceBase = tResourcesCount{"sticks": 1000, "circles": 1000}
+		c.resourceEpoch = now()
+	}
+	mustNoErr(t, inserter.CreateTradeOffer(ctx, "p1", &tradeOffer{id: "o1", cityID: "c1", offered: tResourcesCount{"sticks": 300}, requested: tResourcesCount{"circles": 200}}))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c1"].resourceBase["sticks"], tResourceCount(700))
+	mustEqual(t, state.merchantsInUse("c1"), tResourceCount(300))
+	dbo, err := repository.GetTradeOffer(ctx, "o1")
+	mustNoErr(t, err)
+	mustEqual(t, dbo.playerID, tPlayerID("p1"))
+
+	// the trade settles with a transport each way
+	mustNoErr(t, inserter.AcceptTradeOffer(ctx, "p1", "o1", "c1"))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.AcceptTradeOffer(ctx, "p2", "o1", "c2"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c2"].resourceBase["circles"], tResourceCount(800))
+	_, err = repository.GetTradeOffer(ctx, "o1")
+	mustNoRows(t, err)
+	transports := 0
+	for _, m := range state.movementList {
+		mustEqual(t, m.movementType, transportMovement)
+		transports++
+	}
+	mustEqual(t, transports, 2)
+	clock.Advance(travelTime)
+	processDueEvents(t, eventSourcer, repository, clock, processed)
+	mustEqual(t, len(state.movementList), 0)
+	mustEqual(t, state.cityList["c1"].resourceBase["circles"], tResourceCount(1200))
+	mustEqual(t, state.cityList["c2"].resourceBase["sticks"], tResourceCount(1300))
+	events, err := repository.ListEvents(ctx, clock.Now().Unix())
+	mustNoErr(t, err)
+	mailed := 0
+	for _, e := range events {
+		if e.name != acceptTradeOfferEventName {
+			continue
+		}
+		if _, err = repository.GetMail(ctx, "trade-"+string(e.id), "p1"); err == nil {
+			mailed++
+		}
+	}
+	mustEqual(t, mailed, 1)
+
+	// a cancelled offer gives the resources back
+	mustNoErr(t, inserter.CreateTradeOffer(ctx, "p1", &tradeOffer{id: "o2", cityID: "c1", offered: tResourcesCount{"sticks": 300}, requested: tResourcesCount{"circles": 200}}))
+	processQueued(t, eventSourcer)
+	sticks := state.cityList["c1"].resourceBase["sticks"]
+	mustNoErr(t, inserter.CancelTradeOffer(ctx, "p2", "o2"))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.CancelTradeOffer(ctx, "p1", "o2"))
+	processQueued(t, eventSourcer)
+	mustEqual(t, state.cityList["c1"].resourceBase["sticks"], sticks+300)
+	mustEqual(t, state.merchantsInUse("c1"), tResourceCount(0))
+
+	// the exchange opens with a bigger marketplace, and takes its fee
+	err = inserter.ExchangeResources(ctx, "p1", "c1", "sticks", "circles", 100)
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	c1 := state.cityList["c1"]
+	c1.buildingsLevel["marketplace"] = 2
+	c1.resourceBase = tResourcesCount{"sticks": 1000, "circles": 1000}
+	c1.resourceEpoch = now()
+	eventSourcer.toUpsert.cities["c1"] = struct{}{}
+	mustNoErr(t, eventSourcer.upsertViews(ctx))
+	err = inserter.ExchangeResources(ctx, "p1", "c1", "sticks", "triangles", 100)
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	mustNoErr(t, inserter.ExchangeResources(ctx, "p1", "c1", "sticks", "circles", 2000))
+	mustPreConditionFail()
+	mustNoErr(t, inserter.ExchangeResources(ctx, "p1", "c1", "sticks", "circles", 100))
+	processQueued(t, eventSourcer)
+	mustEqual(t, c1.resourceBase, tResourcesCount{"sticks": 900, "circles": 1090})
+}
+
+// FuzzEventSourcerInvariants drives the event sourcer with random sequences of
+// commands, valid or not, and checks the invariants of the game state on every
+// step. The seeds run as regular tests, `go test -fuzz` explores further.
+func FuzzEventSourcerInvariants(f *testing.F) {
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		d := newInvariantsDriver(t, seed)
+		for i := 1; i <= 500; i++ {
+			d.step()
+			if i%100 == 0 {
+				d.checkReplay()
+			}
+		}
+	})
+}
+
+var (
+	invariantsPlayers   = []string{"p1", "p2"}
+	invariantsCities    = []string{"c1", "c2", "c3", "c4", "c5"}
+	invariantsBuildings = []tBuildingName{"barracks", "mines", "mason", "walls", "marketplace"}
+	invariantsUnits     = []tUnitName{"stickmen", "swordsmen", "god", "catapults"}
+	invariantsResearch  = []tResearchName{"swordsmanship", "masonry", "alchemy"}
+)
+
+// invariantsDriver plays the role of the events worker: commands go through
+// the inserter and every event is processed as soon as it is due, in the same
+// order a re-sync would process them.
+type invariantsDriver struct {
+	t            *testing.T
+	rng          *rand.Rand
+	eventSourcer *EventSourcer
+	inserter     *inserterService
+	repository   *inMemoryRepository
+	clock        *FakeClock
+	processed    map[tEventID]struct{}
+	nextID       int
+}
+
+func newInvariantsDriver(t *testing.T, seed int64) *invariantsDriver {
+	// a small world, so that barbarian villages are right in between the cities
+	configPath := writeTestWorldConfig(t, worldSpecs{
+		Seed:        seed,
+		Size:        10,
+		FeatureSize: 1,
+		Barbarians: barbarianSpecs{
+			Frequency:        0.05,
+			Garrison:         tUnitsCount{"stickmen": 5},
+			Regrowth:         tUnitsCount{"stickmen": 1},
+			RegrowthInterval: 20,
+			Conquerable:      true,
+		},
+	})
+	eventSourcer, inserter, repository, clock := newTestEventSourcerFrom(t, configPath)
+	d := &invariantsDriver{
+		t:            t,
+		rng:          rand.New(rand.NewSource(seed)),
+		eventSourcer: eventSourcer,
+		inserter:     inserter,
+		repository:   repository,
+		clock:        clock,
+		processed:    make(map[tEventID]struct{}),
+	}
+	// a few cities to start with, more might be founded or abandoned later
+	for i, cityID := range invariantsCities[:4] {
+		playerID := invariantsPlayers[i%len(invariantsPlayers)]
+		_ = d.inserter.CreateCity(context.Background(), playerID, &city{id: tCityID(cityID), name: cityID, locationX: d.coordinate(), locationY: d.coordinate()})
+	}
+	d.processDue()
+	return d
+}
+
+func (d *invariantsDriver) step() {
+	d.t.Helper()
+	d.clock.Advance(time.Duration(1+d.rng.Intn(15)) * time.Second)
+	for i := d.rng.Intn(3); i > 0; i-- {
+		d.command()
+	}
+	// the events are picked from the log instead
+	for len(d.eventSourcer.internalEventQueue) > 0 {
+		<-d.eventSourcer.internalEventQueue
+	}
+	d.processDue()
+	d.checkState()
+}
+
+func (d *invariantsDriver) id(prefix string) string {
+	d.nextID++
+	return fmt.Sprintf("%s%d", prefix, d.nextID)
+}
+
+// Coordinates are kept apart so that every movement takes some time, events of
+// the same epoch have no set order.
+func (d *invariantsDriver) coordinate() tCoordinate {
+	return tCoordinate(2 * d.rng.Intn(5))
+}
+
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.Intn(len(values))]
+}
+
+// command issues a random command, most of them will be rejected either by the
+// inserter or on processing.
+func (d *invariantsDriver) command() {
+	ctx := context.Background()
+	playerID := pick(d.rng, invariantsPlayers)
+	cityID := pick(d.rng, invariantsCities)
+	// mostly commands of the owner, so that some of them are valid
+	if c, ok := d.eventSourcer.inMemoryState.cityList[tCityID(cityID)]; ok && d.rng.Intn(5) > 0 {
+		playerID = string(c.playerID)
+	}
+	switch d.rng.Intn(11) {
+	case 0:
+		_ = d.inserter.CreateCity(ctx, playerID, &city{id: tCityID(cityID), name: cityID, locationX: d.coordinate(), locationY: d.coordinate()})
+	case 1:
+		if d.rng.Intn(10) == 0 {
+			_ = d.inserter.DeleteCity(ctx, playerID, cityID)
+		}
+	case 2:
+		item := &buildingQueueItem{
+			id:             tBuildingQueueItemID(d.id("b")),
+			cityID:         tCityID(cityID),
+			targetLevel:    tBuildingLevel(d.rng.Intn(4)),
+			targetBuilding: pick(d.rng, invariantsBuildings),
+		}
+		if c, ok := d.eventSourcer.inMemoryState.cityList[tCityID(cityID)]; ok && d.rng.Intn(4) > 0 {
+			item.targetLevel = c.buildingsLevel[item.targetBuilding] + 1
+		}
+		_ = d.inserter.QueueBuilding(ctx, playerID, item)
+	case 3:
+		_ = d.inserter.QueueResearch(ctx, playerID, &researchQueueItem{
+			id:           tResearchQueueItemID(d.id("r")),
+			cityID:       tCityID(cityID),
+			researchName: pick(d.rng, invariantsResearch),
+		})
+	case 4, 5, 6:
+		_ = d.inserter.QueueUnit(ctx, playerID, &unitQueueItem{
+			id:        tUnitQueueItemID(d.id("u")),
+			cityID:    tCityID(cityID),
+			unitCount: tUnitCount(d.rng.Intn(8) - 1),
+			unitType:  pick(d.rng, invariantsUnits),
+		})
+	case 7:
+		// trades between whatever cities are around, most of them without a marketplace
+		offerIDs := sortedKeys(d.eventSourcer.inMemoryState.tradeOfferList)
+		switch {
+		case len(offerIDs) > 0 && d.rng.Intn(3) == 0:
+			_ = d.inserter.AcceptTradeOffer(ctx, playerID, string(pick(d.rng, offerIDs)), cityID)
+		case len(offerIDs) > 0 && d.rng.Intn(3) == 0:
+			_ = d.inserter.CancelTradeOffer(ctx, playerID, string(pick(d.rng, offerIDs)))
+		case d.rng.Intn(4) == 0:
+			_ = d.inserter.ExchangeResources(ctx, playerID, cityID, "circles", "sticks", tResourceCount(d.rng.Intn(60)))
+		default:
+			_ = d.inserter.CreateTradeOffer(ctx, playerID, &tradeOffer{
+				id:        tTradeOfferID(d.id("o")),
+				cityID:    tCityID(cityID),
+				offered:   tResourcesCount{"sticks": tResourceCount(d.rng.Intn(60))},
+				requested: tResourcesCount{"circles": tResourceCount(d.rng.Intn(60))},
+			})
+		}
+	default:
+		m := &movement{
+			id:            tMovementID(d.id("m")),
+			originID:      tCityID(cityID),
+			destinationX:  d.coordinate(),
+			destinationY:  d.coordinate(),
+			unitCount:     make(tUnitsCount),
+			resourceCount: make(tResourcesCount),
+		}
+		// mostly towards cities, sometimes to forage the wilderness
+		destinationID := tCityID(pick(d.rng, invariantsCities))
+		if villages := d.eventSourcer.configs.Latest().villages; len(villages) > 0 && d.rng.Intn(3) == 0 {
+			village := pick(d.rng, villages)
+			destinationID = villageCityID(village.x, village.y)
+		}
+		if destination, ok := d.eventSourcer.inMemoryState.cityList[destinationID]; ok && d.rng.Intn(4) > 0 {
+			m.destinationID = destination.id
+			m.destinationX = destination.locationX
+			m.destinationY = destination.locationY
+		}
+		for i := d.rng.Intn(3); i >= 0; i-- {
+			unitName := pick(d.rng, invariantsUnits)
+			if d.rng.Intn(3) > 0 {
+				unitName = "stickmen"
+			}
+			m.unitCount[unitName] = tUnitCount(d.rng.Intn(6) - 1)
+		}
+		if d.rng.Intn(3) == 0 {
+			m.resourceCount["sticks"] = tResourceCount(d.rng.Intn(60))
+		}
+		if d.rng.Intn(5) == 0 {
+			m.movementType = transportMovement
+		}
+		_ = d.inserter.StartMovement(ctx, playerID, m)
+	}
+}
+
+// processDue processes the events due one at a time, as processing may insert
+// chain events that are already due as well.
+func (d *invariantsDriver) processDue() {
+	d.t.Helper()
+	ctx := context.Background()
+	for {
+		events, err := d.repository.ListEvents(ctx, d.clock.Now().Unix())
+		mustNoErr(d.t, err)
+		var next *event
+		for _, e := range events {
+			if _, ok := d.processed[e.id]; !ok {
+				next = e
+				break
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		before := totalUnits(d.eventSourcer.inMemoryState)
+		err = d.eventSourcer.processEvent(ctx, next)
+		d.processed[next.id] = struct{}{}
+		if err != nil && !errors.Is(err, errPreConditionFailed) {
+			d.t.Fatalf("process event %s %s: %v", next.name, next.payload, err)
+		}
+		d.checkUnitsConserved(next, err == nil, before, totalUnits(d.eventSourcer.inMemoryState))
+	}
+}
+
+// totalUnits leaves out the barbarian villages, their garrison comes out of
+// nowhere when they spawn and regrow.
+func totalUnits(m *inMemoryStorage) tUnitsCount {
+	total := make(tUnitsCount)
+	for _, c := range m.cityList {
+		if c.playerID == barbarianPlayerID {
+			continue
+		}
+		for unitName, unitCount := range c.unitCount {
+			total[unitName] += unitCount
+		}
+		for _, unitCount := range c.reinforcements {
+			for unitName, count := range unitCount {
+				total[unitName] += count
+			}
+		}
+	}
+	for _, mv := range m.movementList {
+		for unitName, unitCount := range mv.unitCount {
+			total[unitName] += unitCount
+		}
+	}
+	return total
+}
+
+// Units only appear through training, and only disappear in battles or along
+// with the city they were in or returning to.
+func (d *invariantsDriver) checkUnitsConserved(e *event, processed bool, before, after tUnitsCount) {
+	d.t.Helper()
+	want := before
+	if processed && e.name == createUnitEventName {
+		createUnit := createUnitEvent{}
+		mustNoErr(d.t, json.Unmarshal([]byte(e.payload), &createUnit))
+		want = make(tUnitsCount, len(before))
+		for unitName, unitCount := range before {
+			want[unitName] = unitCount
+		}
+		want[createUnit.UnitType] += createUnit.UnitCount
+	}
+	canLose := processed && (e.name == arrivalMovementEventName || e.name == returnMovementEventName || e.name == deleteCityEventName)
+	for _, unitName := range invariantsUnits {
+		if after[unitName] == want[unitName] || (canLose && after[unitName] < want[unitName]) {
+			continue
+		}
+		d.t.Fatalf("event %s %s changed %s units from %d to %d", e.name, e.payload, unitName, before[unitName], after[unitName])
+	}
+}
+
+func (d *invariantsDriver) checkState() {
+	d.t.Helper()
+	ctx := context.Background()
+	state := d.eventSourcer.inMemoryState
+	for cityID, c := range state.cityList {
+		for resourceName, resourceCount := range c.resourceBase {
+			if resourceCount < 0 {
+				d.t.Fatalf("city %s has %d %s", cityID, resourceCount, resourceName)
+			}
+		}
+		for unitName, unitCount := range c.unitCount {
+			if unitCount < 0 {
+				d.t.Fatalf("city %s has %d %s", cityID, unitCount, unitName)
+			}
+		}
+		for playerID, unitCount := range c.reinforcements {
+			for unitName, count := range unitCount {
+				if count < 0 {
+					d.t.Fatalf("city %s has %d %s of %s", cityID, count, unitName, playerID)
+				}
+			}
+		}
+	}
+
+	pending := make(map[tMovementID]struct{})
+	events, err := d.repository.ListEvents(ctx, d.clock.Now().Unix()+1_000_000)
+	mustNoErr(d.t, err)
+	for _, e := range events {
+		if _, ok := d.processed[e.id]; ok || (e.name != arrivalMovementEventName && e.name != returnMovementEventName) {
+			continue
+		}
+		m := struct {
+			MovementID tMovementID `json:"movementID"`
+		}{}
+		mustNoErr(d.t, json.Unmarshal([]byte(e.payload), &m))
+		pending[m.MovementID] = struct{}{}
+	}
+	for movementID, m := range state.movementList {
+		if _, ok := pending[movementID]; !ok {
+			d.t.Fatalf("movement %s has no pending arrival or return", movementID)
+		}
+		for unitName, unitCount := range m.unitCount {
+			if unitCount < 0 {
+				d.t.Fatalf("movement %s has %d %s", movementID, unitCount, unitName)
+			}
+		}
+		for resourceName, resourceCount := range m.resourceCount {
+			if resourceCount < 0 {
+				d.t.Fatalf("movement %s carries %d %s", movementID, resourceCount, resourceName)
+			}
+		}
+	}
+}
+
+// checkReplay re-processes the whole event log from scratch on a copy, it must
+// end up in the very same state.
+func (d *invariantsDriver) checkReplay() {
+	d.t.Helper()
+	ctx := context.Background()
+	events, err := d.repository.ListEvents(ctx, d.clock.Now().Unix())
+	mustNoErr(d.t, err)
+	repository := newInMemoryRepository()
+	for _, e := range events {
+		mustNoErr(d.t, repository.InsertEvent(ctx, e))
+	}
+	replayed := NewEventSourcer(repository, d.eventSourcer.configs, d.clock)
+	mustNoErr(d.t, replayed.reSyncEvents(ctx))
+
+	exportAll := cmp.Exporter(func(reflect.Type) bool { return true })
+	if diff := cmp.Diff(d.eventSourcer.inMemoryState, replayed.inMemoryState, exportAll); diff != "" {
+		d.t.Fatalf("replay differs from the incremental state (-incremental +replay):\n%s", diff)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	api "github.com/luisferreira32/stickerio/api"
 )
@@ -17,21 +22,40 @@ func errHandle(w http.ResponseWriter, err error) {
 	// TODO: might want to distinguish this for lists
 	case errors.Is(err, sql.ErrNoRows):
 		http.Error(w, "not there", http.StatusUnauthorized)
+	case errors.Is(err, errInvalidRequest), errors.Is(err, errInvalidConfig):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, errAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
 	default:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
-func NewServerHandler(repository *StickerioRepository, eventSourcer eventSourcer) *ServerHandler {
+// serverRepository is all the storage the services of the handlers need.
+type serverRepository interface {
+	viewerRepository
+	eventInserter
+	mailRepository
+}
+
+func NewServerHandler(repository serverRepository, eventSourcer eventSourcer, configs *ConfigHistory, clock Clock) *ServerHandler {
 	return &ServerHandler{
 		viewer:   viewerService{repository: repository},
-		inserter: inserterService{repository: repository, eventSourcer: eventSourcer},
+		inserter: inserterService{repository: repository, eventSourcer: eventSourcer, configs: configs, clock: clock},
+		mail:     mailService{repository: repository, clock: clock},
+		worldMap: mapService{eventSourcer: eventSourcer, configs: configs},
+		configs:  configs,
+		clock:    clock,
 	}
 }
 
 type ServerHandler struct {
 	viewer   viewerService
 	inserter inserterService
+	mail     mailService
+	worldMap mapService
+	configs  *ConfigHistory
+	clock    Clock
 }
 
 func (s *ServerHandler) GetWelcome(w http.ResponseWriter, r *http.Request) {
@@ -88,7 +112,10 @@ func (s *ServerHandler) GetCityInfo(w http.ResponseWriter, r *http.Request) {
 
 func (s *ServerHandler) ListCityInfo(w http.ResponseWriter, r *http.Request) {
 	playerIDFilter := r.URL.Query().Get(PlayerID.String())
-	locationBoundsFilter := r.URL.Query().Get(LocationBounds.String())
+	// arrays come either comma separated or as repeated parameters
+	locationBoundsFilter := strings.Join(r.URL.Query()[LocationBounds.String()], ",")
+	nearFilter := strings.Join(r.URL.Query()[Near.String()], ",")
+	radiusFilter := r.URL.Query().Get(Radius.String())
 	lastID := r.Context().Value(LastIDKey).(string)
 	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
 	if err != nil {
@@ -96,7 +123,7 @@ func (s *ServerHandler) ListCityInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cities, err := s.viewer.ListCityInfo(r.Context(), lastID, pageSize, playerIDFilter, locationBoundsFilter)
+	cities, err := s.viewer.ListCityInfo(r.Context(), lastID, pageSize, playerIDFilter, locationBoundsFilter, nearFilter, radiusFilter)
 	if err != nil {
 		errHandle(w, err)
 		return
@@ -323,6 +350,7 @@ func (s *ServerHandler) StartMovement(w http.ResponseWriter, r *http.Request) {
 		destinationY:  tCoordinate(m.DestinationY),
 		resourceCount: fromUntypedMap[tResourceName, tResourceCount](m.ResourceCount),
 		unitCount:     fromUntypedMap[tUnitName, tUnitCount](m.UnitCount),
+		movementType:  tMovementType(m.GetType()),
 	})
 	if err != nil {
 		errHandle(w, err)
@@ -357,6 +385,93 @@ func (s *ServerHandler) QueueUnit(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (s *ServerHandler) ListResearchQueueItems(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	cityID := r.Context().Value(CityIDKey).(string)
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	items, err := s.viewer.ListResearchQueueItems(r.Context(), cityID, playerID, lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1ResearchQueueItem, len(items))
+	for i := 0; i < len(items); i++ {
+		resp[i] = researchQueueItemToAPIModel(items[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) QueueResearch(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	cityID := r.Context().Value(CityIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	item := api.V1ResearchQueueItem{}
+	err := decoder.Decode(&item)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.QueueResearch(r.Context(), playerID, &researchQueueItem{
+		id:           tResearchQueueItemID(item.Id),
+		cityID:       tCityID(cityID),
+		researchName: tResearchName(item.Research),
+	})
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) GetPlayerResearch(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	research, err := s.viewer.GetPlayerResearch(r.Context(), playerID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := api.V1PlayerResearch{
+		Research: make([]string, len(research)),
+	}
+	for i := 0; i < len(research); i++ {
+		resp.Research[i] = string(research[i])
+	}
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
 func (s *ServerHandler) QueueBuilding(w http.ResponseWriter, r *http.Request) {
 	playerID := r.Context().Value(PlayerIDKey).(string)
 	cityID := r.Context().Value(CityIDKey).(string)
@@ -421,3 +536,611 @@ func (s *ServerHandler) DeleteCity(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusAccepted)
 }
+
+func (s *ServerHandler) GetAlliance(w http.ResponseWriter, r *http.Request) {
+	allianceID := r.Context().Value(AllianceIDKey).(string)
+	alliance, err := s.viewer.GetAlliance(r.Context(), allianceID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := allianceToAPIModel(alliance)
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) ListAllianceInfo(w http.ResponseWriter, r *http.Request) {
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	alliances, err := s.viewer.ListAllianceInfo(r.Context(), lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1AllianceInfo, len(alliances))
+	for i := 0; i < len(alliances); i++ {
+		resp[i] = allianceToAllianceInfoAPIModel(alliances[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) CreateAlliance(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	a := api.V1AllianceInfo{}
+	err := decoder.Decode(&a)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.CreateAlliance(r.Context(), playerID, &alliance{
+		id:       tAllianceID(a.Id),
+		name:     a.Name,
+		leaderID: tPlayerID(playerID),
+	})
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) InviteToAlliance(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	allianceID := r.Context().Value(AllianceIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	invite := api.V1AllianceInvite{}
+	err := decoder.Decode(&invite)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.InviteToAlliance(r.Context(), playerID, allianceID, invite.PlayerID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) JoinAlliance(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	allianceID := r.Context().Value(AllianceIDKey).(string)
+
+	err := s.inserter.JoinAlliance(r.Context(), playerID, allianceID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) LeaveAlliance(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	allianceID := r.Context().Value(AllianceIDKey).(string)
+
+	err := s.inserter.LeaveAlliance(r.Context(), playerID, allianceID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) RecallReinforcements(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	cityID := r.Context().Value(CityIDKey).(string)
+
+	err := s.inserter.RecallReinforcements(r.Context(), playerID, cityID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) KickFromAlliance(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	allianceID := r.Context().Value(AllianceIDKey).(string)
+	memberID := r.Context().Value(MemberIDKey).(string)
+
+	err := s.inserter.KickFromAlliance(r.Context(), playerID, allianceID, memberID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) GetTradeOffer(w http.ResponseWriter, r *http.Request) {
+	tradeOfferID := r.Context().Value(TradeOfferIDKey).(string)
+	offer, err := s.viewer.GetTradeOffer(r.Context(), tradeOfferID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := tradeOfferToAPIModel(offer)
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) ListTradeOffers(w http.ResponseWriter, r *http.Request) {
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	offers, err := s.viewer.ListTradeOffers(r.Context(), lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1TradeOffer, len(offers))
+	for i := 0; i < len(offers); i++ {
+		resp[i] = tradeOfferToAPIModel(offers[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) CreateTradeOffer(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	o := api.V1TradeOffer{}
+	err := decoder.Decode(&o)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.CreateTradeOffer(r.Context(), playerID, &tradeOffer{
+		id:        tTradeOfferID(o.Id),
+		cityID:    tCityID(o.CityID),
+		offered:   fromUntypedMap[tResourceName, tResourceCount](o.Offered),
+		requested: fromUntypedMap[tResourceName, tResourceCount](o.Requested),
+	})
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) AcceptTradeOffer(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	tradeOfferID := r.Context().Value(TradeOfferIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	acceptance := api.V1TradeOfferAcceptance{}
+	err := decoder.Decode(&acceptance)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.AcceptTradeOffer(r.Context(), playerID, tradeOfferID, acceptance.CityID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) CancelTradeOffer(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	tradeOfferID := r.Context().Value(TradeOfferIDKey).(string)
+
+	err := s.inserter.CancelTradeOffer(r.Context(), playerID, tradeOfferID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) ExchangeResources(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	exchange := api.V1ResourceExchange{}
+	err := decoder.Decode(&exchange)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.inserter.ExchangeResources(r.Context(), playerID, exchange.CityID, tResourceName(exchange.From), tResourceName(exchange.To), tResourceCount(exchange.Count))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) SendMail(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	decoder := json.NewDecoder(r.Body)
+	m := api.V1Mail{}
+	err := decoder.Decode(&m)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	err = s.mail.SendMail(r.Context(), playerID, &mail{
+		id:          tMailID(m.Id),
+		recipientID: tPlayerID(m.RecipientID),
+		allianceID:  tAllianceID(m.AllianceID),
+		subject:     m.Subject,
+		content:     m.Content,
+	})
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ServerHandler) GetMail(w http.ResponseWriter, r *http.Request) {
+	mailID := r.Context().Value(MailIDKey).(string)
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	m, err := s.mail.GetMail(r.Context(), mailID, playerID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := mailToAPIModel(m)
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) ListInbox(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	mails, err := s.mail.ListInbox(r.Context(), playerID, lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1Mail, len(mails))
+	for i := 0; i < len(mails); i++ {
+		resp[i] = mailToAPIModel(mails[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) ListOutbox(w http.ResponseWriter, r *http.Request) {
+	playerID := r.Context().Value(PlayerIDKey).(string)
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	mails, err := s.mail.ListOutbox(r.Context(), playerID, lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1Mail, len(mails))
+	for i := 0; i < len(mails); i++ {
+		resp[i] = mailToAPIModel(mails[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) MarkMailRead(w http.ResponseWriter, r *http.Request) {
+	mailID := r.Context().Value(MailIDKey).(string)
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	err := s.mail.MarkMailRead(r.Context(), mailID, playerID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *ServerHandler) DeleteMail(w http.ResponseWriter, r *http.Request) {
+	mailID := r.Context().Value(MailIDKey).(string)
+	playerID := r.Context().Value(PlayerIDKey).(string)
+
+	err := s.mail.DeleteMail(r.Context(), mailID, playerID)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *ServerHandler) ListLeaderboard(w http.ResponseWriter, r *http.Request) {
+	kind := r.Context().Value(LeaderboardKindKey).(string)
+	lastID := r.Context().Value(LastIDKey).(string)
+	pageSize, err := strconv.Atoi(r.Context().Value(PageSizeKey).(string))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	entries, err := s.viewer.ListLeaderboard(r.Context(), kind, lastID, pageSize)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	resp := make([]api.V1LeaderboardEntry, len(entries))
+	for i := 0; i < len(entries); i++ {
+		resp[i] = leaderboardEntryToAPIModel(entries[i])
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) GetMap(w http.ResponseWriter, r *http.Request) {
+	bounds := make([]tCoordinate, 4)
+	for i, key := range []QueryParameterKey{X1, Y1, X2, Y2} {
+		value, err := strconv.ParseInt(r.URL.Query().Get(key.String()), 10, 32)
+		if err != nil {
+			errHandle(w, fmt.Errorf("%w: %s must be an integer between %d and %d", errInvalidRequest, key, math.MinInt32, math.MaxInt32))
+			return
+		}
+		bounds[i] = tCoordinate(value)
+	}
+	zoomedOut := r.URL.Query().Get(ZoomedOut.String()) == "true"
+
+	tiles, regions, err := s.worldMap.GetMap(bounds[0], bounds[1], bounds[2], bounds[3], zoomedOut)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	respBytes, err := json.Marshal(mapToAPIModel(tiles, regions))
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+func (s *ServerHandler) GetRequirements(w http.ResponseWriter, r *http.Request) {
+	resp := requirementsToAPIModel(s.configs.Latest())
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+// The ETag is the configuration hash, clients can cache the configuration
+// until the world is rebalanced.
+func (s *ServerHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := s.configs.Latest()
+	etag := fmt.Sprintf("%q", cfg.hash)
+	w.Header().Set("ETag", etag)
+	if noneMatch(r.Header.Values("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	resp := gameConfigToAPIModel(cfg.gameConfig)
+	respBytes, err := resp.MarshalJSON()
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+}
+
+// Activates the config in the request body for the world, effective from now on.
+func (s *ServerHandler) ActivateConfig(w http.ResponseWriter, r *http.Request) {
+	rawConfig, err := io.ReadAll(r.Body)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	cfg, err := s.configs.Activate(r.Context(), rawConfig)
+	if err != nil {
+		errHandle(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", fmt.Sprintf("%q", cfg.hash))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Moves the world clock forward by the seconds in the query, only dev builds
+// have a clock that can be advanced.
+func (s *ServerHandler) AdvanceClock(w http.ResponseWriter, r *http.Request) {
+	clock, ok := s.clock.(interface{ Advance(d time.Duration) })
+	if !ok {
+		http.Error(w, "clock cannot be advanced", http.StatusNotFound)
+		return
+	}
+	seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+	if err != nil || seconds <= 0 {
+		errHandle(w, fmt.Errorf("%w: seconds must be a positive integer", errInvalidRequest))
+		return
+	}
+
+	clock.Advance(time.Duration(seconds) * time.Second)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// noneMatch tells whether an If-None-Match header matches the entity tag, it is
+// a list of tags or "*" for any, compared weakly as RFC 9110 section 13.1.2 asks.
+func noneMatch(ifNoneMatch []string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, header := range ifNoneMatch {
+		for {
+			header = strings.TrimLeft(header, " \t,")
+			if header == "" {
+				break
+			}
+			if header[0] == '*' {
+				return true
+			}
+			header = strings.TrimPrefix(header, "W/")
+			// tags are quoted and may have commas within, a malformed list matches nothing
+			if !strings.HasPrefix(header, `"`) {
+				return false
+			}
+			end := strings.IndexByte(header[1:], '"') + 2
+			if end == 1 {
+				return false
+			}
+			if header[:end] == etag {
+				return true
+			}
+			header = header[end:]
+		}
+	}
+	return false
+}
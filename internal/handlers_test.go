@@ -1 +1,129 @@
 package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestGetConfig(t *testing.T) {
+	configs, err := NewConfigHistory(context.Background(), newInMemoryRepository(), NewFakeClock(time.Unix(1_000_000, 0)), "../config.json")
+	mustNoErr(t, err)
+	s := &ServerHandler{configs: configs}
+	etag := `"` + configs.Latest().hash + `"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch []string
+		wantStatus  int
+	}{
+		{name: "no cached config", wantStatus: http.StatusOK},
+		{name: "cached config", ifNoneMatch: []string{etag}, wantStatus: http.StatusNotModified},
+		{name: "weakly cached config", ifNoneMatch: []string{"W/" + etag}, wantStatus: http.StatusNotModified},
+		{name: "any cached config", ifNoneMatch: []string{"*"}, wantStatus: http.StatusNotModified},
+		{name: "one of the cached configs", ifNoneMatch: []string{`"old", W/"older",` + etag}, wantStatus: http.StatusNotModified},
+		{name: "one of the cached configs over headers", ifNoneMatch: []string{`"old"`, etag}, wantStatus: http.StatusNotModified},
+		{name: "other cached configs", ifNoneMatch: []string{`"old", W/"a,b"`}, wantStatus: http.StatusOK},
+		{name: "unquoted cached config", ifNoneMatch: []string{configs.Latest().hash}, wantStatus: http.StatusOK},
+		{name: "malformed cached config", ifNoneMatch: []string{`W/`, `"` + configs.Latest().hash}, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1/config", nil)
+			for _, value := range tt.ifNoneMatch {
+				r.Header.Add("If-None-Match", value)
+			}
+			w := httptest.NewRecorder()
+			s.GetConfig(w, r)
+			mustEqual(t, w.Code, tt.wantStatus)
+			mustEqual(t, w.Header().Get("ETag"), etag)
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() > 0 {
+				t.Fatalf("expected no body, got %s", w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.Len() == 0 {
+				t.Fatal("expected the config in the body")
+			}
+		})
+	}
+}
+
+func TestRouterWorlds(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Unix(1_000_000, 0))
+	speedConfigPath := filepath.Join(t.TempDir(), "config.json")
+	mustNoErr(t, os.WriteFile(speedConfigPath, rebalancedConfig(t, func(c map[string]any) { c["worldSpeed"] = 2 }), 0o644))
+	worldRouters := make(map[string]func(chi.Router))
+	etags := make(map[string]string)
+	for worldID, configPath := range map[string]string{"classic": "../config.json", "speed": speedConfigPath} {
+		repository := newInMemoryRepository()
+		configs, err := NewConfigHistory(ctx, repository, clock, configPath)
+		mustNoErr(t, err)
+		handlers := NewServerHandler(repository, NewEventSourcer(repository, configs, clock), configs, clock)
+		worldRouters[worldID] = WorldRoutes(handlers, "")
+		etags[worldID] = `"` + configs.Latest().hash + `"`
+	}
+	router := NewRouter("classic", worldRouters)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantETag   string
+	}{
+		// the default world is served without the prefix too, for the clients that predate worlds
+		{path: "/v1/config", wantStatus: http.StatusOK, wantETag: etags["classic"]},
+		{path: "/v1/worlds/classic/config", wantStatus: http.StatusOK, wantETag: etags["classic"]},
+		{path: "/v1/worlds/speed/config", wantStatus: http.StatusOK, wantETag: etags["speed"]},
+		{path: "/v1/worlds/unknown/config", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			mustEqual(t, w.Code, tt.wantStatus)
+			mustEqual(t, w.Header().Get("ETag"), tt.wantETag)
+		})
+	}
+	if etags["classic"] == etags["speed"] {
+		t.Fatal("expected the worlds to have configs of their own")
+	}
+}
+
+func TestGetMap(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Unix(1_000_000, 0))
+	boundedConfigPath := filepath.Join(t.TempDir(), "config.json")
+	mustNoErr(t, os.WriteFile(boundedConfigPath, rebalancedConfig(t, func(c map[string]any) {
+		c["world"].(map[string]any)["size"] = 10
+	}), 0o644))
+
+	tests := []struct {
+		name       string
+		configPath string
+		query      string
+		wantStatus int
+	}{
+		{name: "a few tiles", configPath: "../config.json", query: "x1=-5&y1=-5&x2=5&y2=5", wantStatus: http.StatusOK},
+		{name: "the whole unbounded world", configPath: "../config.json", query: "x1=-2147483648&y1=-2147483648&x2=2147483647&y2=2147483647", wantStatus: http.StatusBadRequest},
+		{name: "the whole unbounded world zoomed out", configPath: "../config.json", query: "x1=-2147483648&y1=-2147483648&x2=2147483647&y2=2147483647&zoomedOut=true", wantStatus: http.StatusBadRequest},
+		{name: "beyond the coordinates", configPath: "../config.json", query: "x1=-2147483649&y1=0&x2=0&y2=0", wantStatus: http.StatusBadRequest},
+		{name: "the whole bounded world", configPath: boundedConfigPath, query: "x1=-2147483648&y1=-2147483648&x2=2147483647&y2=2147483647", wantStatus: http.StatusOK},
+		{name: "outside of the bounded world", configPath: boundedConfigPath, query: "x1=100&y1=100&x2=200&y2=200", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newInMemoryRepository()
+			configs, err := NewConfigHistory(ctx, repository, clock, tt.configPath)
+			mustNoErr(t, err)
+			s := NewServerHandler(repository, NewEventSourcer(repository, configs, clock), configs, clock)
+			w := httptest.NewRecorder()
+			s.GetMap(w, httptest.NewRequest(http.MethodGet, "/v1/map?"+tt.query, nil))
+			mustEqual(t, w.Code, tt.wantStatus)
+		})
+	}
+}
@@ -0,0 +1,767 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// inMemoryRepository keeps the events and the view tables in maps, with the
+// same semantics as the SQL queries of the StickerioRepository. It allows to
+// exercise the event sourcer, services and handlers without a database.
+type inMemoryRepository struct {
+	lock *sync.Mutex
+
+	events         map[tEventID]event
+	cities         map[tCityID]dbCity
+	movements      map[tMovementID]dbMovement
+	unitQueue      map[tUnitQueueItemID]dbUnitQueueItem
+	buildingQueue  map[tBuildingQueueItemID]dbBuildingQueueItem
+	researchQueue  map[tResearchQueueItemID]dbResearchQueueItem
+	alliances      map[tAllianceID]dbAlliance
+	players        map[tPlayerID]dbPlayer
+	tradeOffers    map[tTradeOfferID]dbTradeOffer
+	mail           map[tMailID]inMemoryMail
+	inbox          map[tMailID]map[tPlayerID]*inMemoryInboxEntry
+	configVersions []dbConfigVersion
+}
+
+type inMemoryMail struct {
+	dbMail
+	senderDeleted bool
+}
+
+type inMemoryInboxEntry struct {
+	read    bool
+	deleted bool
+}
+
+func newInMemoryRepository() *inMemoryRepository {
+	return &inMemoryRepository{
+		lock:           &sync.Mutex{},
+		events:         make(map[tEventID]event),
+		cities:         make(map[tCityID]dbCity),
+		movements:      make(map[tMovementID]dbMovement),
+		unitQueue:      make(map[tUnitQueueItemID]dbUnitQueueItem),
+		buildingQueue:  make(map[tBuildingQueueItemID]dbBuildingQueueItem),
+		researchQueue:  make(map[tResearchQueueItemID]dbResearchQueueItem),
+		alliances:      make(map[tAllianceID]dbAlliance),
+		players:        make(map[tPlayerID]dbPlayer),
+		tradeOffers:    make(map[tTradeOfferID]dbTradeOffer),
+		mail:           make(map[tMailID]inMemoryMail),
+		inbox:          make(map[tMailID]map[tPlayerID]*inMemoryInboxEntry),
+		configVersions: make([]dbConfigVersion, 0),
+	}
+}
+
+// listPage returns the rows kept by the filter with an ID after lastID, ordered
+// by ID and limited to the page size. Like LIMIT, a negative page size is no limit.
+func listPage[K ~string, V any](rows map[K]V, lastID string, pageSize int, keep func(V) bool) []V {
+	ids := make([]K, 0, len(rows))
+	for id := range rows {
+		if string(id) <= lastID || !keep(rows[id]) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if pageSize >= 0 && len(ids) > pageSize {
+		ids = ids[:pageSize]
+	}
+
+	results := make([]V, len(ids))
+	for i, id := range ids {
+		results[i] = rows[id]
+	}
+	return results
+}
+
+func keepAll[V any](V) bool { return true }
+
+func (r *inMemoryRepository) InsertEvent(_ context.Context, e *event) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.events[e.id]; ok {
+		return nil
+	}
+	r.events[e.id] = *e
+	return nil
+}
+
+func (r *inMemoryRepository) ListEvents(_ context.Context, untilEpoch int64) ([]*event, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	results := make([]*event, 0)
+	for _, e := range r.events {
+		if int64(e.epoch) > untilEpoch {
+			continue
+		}
+		e := e
+		results = append(results, &e)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].epoch != results[j].epoch {
+			return results[i].epoch < results[j].epoch
+		}
+		return results[i].id < results[j].id
+	})
+	return results, nil
+}
+
+// A city can be seen in full by its owner and by the members of the owner's alliance.
+func (r *inMemoryRepository) GetCity(_ context.Context, id, playerID string) (*dbCity, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	c, ok := r.cities[tCityID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getCity: %w", sql.ErrNoRows)
+	}
+	if string(c.playerID) != playerID {
+		me, meOk := r.players[tPlayerID(playerID)]
+		owner, ownerOk := r.players[c.playerID]
+		if !meOk || !ownerOk || me.allianceID == "" || me.allianceID != owner.allianceID {
+			return nil, fmt.Errorf("getCity: %w", sql.ErrNoRows)
+		}
+	}
+	return &c, nil
+}
+
+func cityInfo(c dbCity) *dbCity {
+	return &dbCity{
+		id:             c.id,
+		name:           c.name,
+		playerID:       c.playerID,
+		locationX:      c.locationX,
+		locationY:      c.locationY,
+		protectedUntil: c.protectedUntil,
+	}
+}
+
+func (r *inMemoryRepository) GetCityInfo(_ context.Context, id string) (*dbCity, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	c, ok := r.cities[tCityID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getCityInfo: %w", sql.ErrNoRows)
+	}
+	return cityInfo(c), nil
+}
+
+// NOTE: the filters are SQL conditions, so they are matched on the column they filter.
+func matchCityFilter(c dbCity, filter listCityInfoFilterOpt) (bool, error) {
+	q, v := filter()
+	switch q {
+	case "player_id=":
+		return string(c.playerID) == v.(string), nil
+	case "location_x>=":
+		return int(c.locationX) >= v.(int), nil
+	case "location_y>=":
+		return int(c.locationY) >= v.(int), nil
+	case "location_x<=":
+		return int(c.locationX) <= v.(int), nil
+	case "location_y<=":
+		return int(c.locationY) <= v.(int), nil
+	}
+	return false, fmt.Errorf("unsupported city filter %s", q)
+}
+
+func (r *inMemoryRepository) ListCityInfo(_ context.Context, lastID string, pageSize int, filters ...listCityInfoFilterOpt) ([]*dbCity, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var filterErr error
+	page := listPage(r.cities, lastID, pageSize, func(c dbCity) bool {
+		for _, filter := range filters {
+			ok, err := matchCityFilter(c, filter)
+			if err != nil {
+				filterErr = err
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	results := make([]*dbCity, len(page))
+	for i, c := range page {
+		results[i] = cityInfo(c)
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) ListCityInfoNear(_ context.Context, x, y tCoordinate, radius int, lastID string, pageSize int, filters ...listCityInfoFilterOpt) ([]*dbCity, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	distance := func(c dbCity) int64 {
+		dx, dy := int64(c.locationX-x), int64(c.locationY-y)
+		return dx*dx + dy*dy
+	}
+
+	var filterErr error
+	nearby := listPage(r.cities, "", -1, func(c dbCity) bool {
+		if radius >= 0 && distance(c) > int64(radius)*int64(radius) {
+			return false
+		}
+		for _, filter := range filters {
+			ok, err := matchCityFilter(c, filter)
+			if err != nil {
+				filterErr = err
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+	sort.SliceStable(nearby, func(i, j int) bool { return distance(nearby[i]) < distance(nearby[j]) })
+
+	lastDistance := int64(-1)
+	if lastID != "" {
+		last, ok := r.cities[tCityID(lastID)]
+		if !ok {
+			return nil, fmt.Errorf("%w: no city %s to list after", errInvalidRequest, lastID)
+		}
+		lastDistance = distance(last)
+	}
+	results := make([]*dbCity, 0, pageSize)
+	for _, c := range nearby {
+		if len(results) == pageSize {
+			break
+		}
+		if distance(c) < lastDistance || (distance(c) == lastDistance && string(c.id) <= lastID) {
+			continue
+		}
+		results = append(results, cityInfo(c))
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) UpsertCity(_ context.Context, c *dbCity) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cities[c.id] = *c
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteCity(_ context.Context, cityID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.cities, tCityID(cityID))
+	return nil
+}
+
+func (r *inMemoryRepository) GetMovement(_ context.Context, id, playerID string) (*dbMovement, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	m, ok := r.movements[tMovementID(id)]
+	if !ok || string(m.playerID) != playerID {
+		return nil, fmt.Errorf("getMovement: %w", sql.ErrNoRows)
+	}
+	return &m, nil
+}
+
+func matchMovementFilter(m dbMovement, filter listMovementsFilterOpt) (bool, error) {
+	q, v := filter()
+	switch q {
+	case "origin_id=":
+		return string(m.originID) == v.(string), nil
+	case "destination_id=":
+		return string(m.destinationID) == v.(string), nil
+	}
+	return false, fmt.Errorf("unsupported movement filter %s", q)
+}
+
+func (r *inMemoryRepository) ListMovements(_ context.Context, playerID, lastID string, pageSize int, filters ...listMovementsFilterOpt) ([]*dbMovement, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var filterErr error
+	page := listPage(r.movements, lastID, pageSize, func(m dbMovement) bool {
+		if string(m.playerID) != playerID {
+			return false
+		}
+		for _, filter := range filters {
+			ok, err := matchMovementFilter(m, filter)
+			if err != nil {
+				filterErr = err
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+	return toPointers(page), nil
+}
+
+func toPointers[V any](values []V) []*V {
+	pointers := make([]*V, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	return pointers
+}
+
+func (r *inMemoryRepository) UpsertMovement(_ context.Context, m *dbMovement) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.movements[m.id] = *m
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteMovement(_ context.Context, movementID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.movements, tMovementID(movementID))
+	return nil
+}
+
+func (r *inMemoryRepository) GetUnitQueueItem(_ context.Context, id, cityID, playerID string) (*dbUnitQueueItem, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	item, ok := r.unitQueue[tUnitQueueItemID(id)]
+	if !ok || string(item.cityID) != cityID || string(item.playerID) != playerID {
+		return nil, fmt.Errorf("getUnitQueueItem: %w", sql.ErrNoRows)
+	}
+	return &item, nil
+}
+
+func (r *inMemoryRepository) ListUnitQueueItems(_ context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbUnitQueueItem, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return toPointers(listPage(r.unitQueue, lastID, pageSize, func(item dbUnitQueueItem) bool {
+		return string(item.cityID) == cityID && string(item.playerID) == playerID
+	})), nil
+}
+
+func (r *inMemoryRepository) UpsertUnitQueueItem(_ context.Context, m *dbUnitQueueItem) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.unitQueue[m.id] = *m
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteUnitQueueItem(_ context.Context, unitQueueItemID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.unitQueue, tUnitQueueItemID(unitQueueItemID))
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteUnitQueueItemsFromCity(_ context.Context, cityID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for id, item := range r.unitQueue {
+		if string(item.cityID) == cityID {
+			delete(r.unitQueue, id)
+		}
+	}
+	return nil
+}
+
+func (r *inMemoryRepository) GetBuildingQueueItem(_ context.Context, id, cityID, playerID string) (*dbBuildingQueueItem, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	item, ok := r.buildingQueue[tBuildingQueueItemID(id)]
+	if !ok || string(item.cityID) != cityID || string(item.playerID) != playerID {
+		return nil, fmt.Errorf("getBuildingQueueItem: %w", sql.ErrNoRows)
+	}
+	return &item, nil
+}
+
+func (r *inMemoryRepository) ListBuildingQueueItems(_ context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbBuildingQueueItem, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return toPointers(listPage(r.buildingQueue, lastID, pageSize, func(item dbBuildingQueueItem) bool {
+		return string(item.cityID) == cityID && string(item.playerID) == playerID
+	})), nil
+}
+
+func (r *inMemoryRepository) UpsertBuildingQueueItem(_ context.Context, m *dbBuildingQueueItem) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.buildingQueue[m.id] = *m
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteBuildingQueueItem(_ context.Context, buildingQueueItemID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.buildingQueue, tBuildingQueueItemID(buildingQueueItemID))
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteBuildingQueueItemsFromCity(_ context.Context, cityID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for id, item := range r.buildingQueue {
+		if string(item.cityID) == cityID {
+			delete(r.buildingQueue, id)
+		}
+	}
+	return nil
+}
+
+func (r *inMemoryRepository) ListResearchQueueItems(_ context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbResearchQueueItem, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return toPointers(listPage(r.researchQueue, lastID, pageSize, func(item dbResearchQueueItem) bool {
+		return string(item.cityID) == cityID && string(item.playerID) == playerID
+	})), nil
+}
+
+func (r *inMemoryRepository) UpsertResearchQueueItem(_ context.Context, m *dbResearchQueueItem) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.researchQueue[m.id] = *m
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteResearchQueueItem(_ context.Context, researchQueueItemID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.researchQueue, tResearchQueueItemID(researchQueueItemID))
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteResearchQueueItemsFromCity(_ context.Context, cityID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for id, item := range r.researchQueue {
+		if string(item.cityID) == cityID {
+			delete(r.researchQueue, id)
+		}
+	}
+	return nil
+}
+
+func (r *inMemoryRepository) GetPlayerResearch(_ context.Context, playerID string) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.players[tPlayerID(playerID)].research, nil
+}
+
+func (r *inMemoryRepository) GetAlliance(_ context.Context, id string) (*dbAlliance, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	a, ok := r.alliances[tAllianceID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getAlliance: %w", sql.ErrNoRows)
+	}
+	a.members = make([]tPlayerID, 0)
+	for playerID, p := range r.players {
+		if string(p.allianceID) == id {
+			a.members = append(a.members, playerID)
+		}
+	}
+	sort.Slice(a.members, func(i, j int) bool { return a.members[i] < a.members[j] })
+	return &a, nil
+}
+
+func (r *inMemoryRepository) ListAllianceInfo(_ context.Context, lastID string, pageSize int) ([]*dbAlliance, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	page := listPage(r.alliances, lastID, pageSize, keepAll[dbAlliance])
+	results := make([]*dbAlliance, len(page))
+	for i, a := range page {
+		results[i] = &dbAlliance{
+			id:       a.id,
+			name:     a.name,
+			leaderID: a.leaderID,
+		}
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) UpsertAlliance(_ context.Context, a *dbAlliance) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stored := *a
+	stored.members = nil
+	r.alliances[a.id] = stored
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteAlliance(_ context.Context, allianceID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.alliances, tAllianceID(allianceID))
+	return nil
+}
+
+func (r *inMemoryRepository) GetTradeOffer(_ context.Context, id string) (*dbTradeOffer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	o, ok := r.tradeOffers[tTradeOfferID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getTradeOffer: %w", sql.ErrNoRows)
+	}
+	return &o, nil
+}
+
+func (r *inMemoryRepository) ListTradeOffers(_ context.Context, lastID string, pageSize int) ([]*dbTradeOffer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	page := listPage(r.tradeOffers, lastID, pageSize, keepAll[dbTradeOffer])
+	results := make([]*dbTradeOffer, len(page))
+	for i := range page {
+		results[i] = &page[i]
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) GetPlayer(_ context.Context, id string) (*dbPlayer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	p, ok := r.players[tPlayerID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getPlayer: %w", sql.ErrNoRows)
+	}
+	return &p, nil
+}
+
+func (r *inMemoryRepository) UpsertPlayer(_ context.Context, p *dbPlayer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.players[p.id] = *p
+	return nil
+}
+
+// Writes to memory cannot fail half way, so the views are simply written one
+// after the other.
+func (r *inMemoryRepository) UpsertViews(ctx context.Context, v *dbViews) error {
+	for _, e := range v.events {
+		_ = r.InsertEvent(ctx, e)
+	}
+	for _, c := range v.cities {
+		_ = r.UpsertCity(ctx, c)
+	}
+	for _, id := range v.deletedCities {
+		_ = r.DeleteCity(ctx, string(id))
+		_ = r.DeleteBuildingQueueItemsFromCity(ctx, string(id))
+		_ = r.DeleteUnitQueueItemsFromCity(ctx, string(id))
+		_ = r.DeleteResearchQueueItemsFromCity(ctx, string(id))
+	}
+	for _, m := range v.movements {
+		_ = r.UpsertMovement(ctx, m)
+	}
+	for _, id := range v.deletedMovements {
+		_ = r.DeleteMovement(ctx, string(id))
+	}
+	for _, item := range v.unitQueueItems {
+		_ = r.UpsertUnitQueueItem(ctx, item)
+	}
+	for _, id := range v.deletedUnitQueueItems {
+		_ = r.DeleteUnitQueueItem(ctx, string(id))
+	}
+	for _, item := range v.buildingQueueItems {
+		_ = r.UpsertBuildingQueueItem(ctx, item)
+	}
+	for _, id := range v.deletedBuildingQueueItems {
+		_ = r.DeleteBuildingQueueItem(ctx, string(id))
+	}
+	for _, item := range v.researchQueueItems {
+		_ = r.UpsertResearchQueueItem(ctx, item)
+	}
+	for _, id := range v.deletedResearchQueueItems {
+		_ = r.DeleteResearchQueueItem(ctx, string(id))
+	}
+	for _, a := range v.alliances {
+		_ = r.UpsertAlliance(ctx, a)
+	}
+	for _, id := range v.deletedAlliances {
+		_ = r.DeleteAlliance(ctx, string(id))
+	}
+	for _, p := range v.players {
+		_ = r.UpsertPlayer(ctx, p)
+	}
+	for _, d := range v.mails {
+		_ = r.InsertMail(ctx, d.mail, d.recipients)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, o := range v.tradeOffers {
+		r.tradeOffers[o.id] = *o
+	}
+	for _, id := range v.deletedTradeOffers {
+		delete(r.tradeOffers, id)
+	}
+	return nil
+}
+
+func (r *inMemoryRepository) ListLeaderboard(_ context.Context, kind tLeaderboardKind, lastID string, pageSize int) ([]*dbLeaderboardEntry, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ranked := make([]*dbLeaderboardEntry, 0)
+	switch kind {
+	case overallLeaderboard, attackLeaderboard, defenceLeaderboard:
+		for _, p := range r.players {
+			score := p.score
+			switch kind {
+			case attackLeaderboard:
+				score = p.attackPoints
+			case defenceLeaderboard:
+				score = p.defencePoints
+			}
+			ranked = append(ranked, &dbLeaderboardEntry{id: string(p.id), score: score})
+		}
+	case allianceLeaderboard:
+		for _, a := range r.alliances {
+			entry := &dbLeaderboardEntry{id: string(a.id)}
+			for _, p := range r.players {
+				if p.allianceID == a.id {
+					entry.score += p.score
+				}
+			}
+			ranked = append(ranked, entry)
+		}
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %s", kind)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	start := 0
+	for i, entry := range ranked {
+		entry.rank = int64(i + 1)
+		if entry.id == lastID {
+			start = i + 1
+		}
+	}
+	ranked = ranked[start:]
+	if pageSize >= 0 && len(ranked) > pageSize {
+		ranked = ranked[:pageSize]
+	}
+	return ranked, nil
+}
+
+func (r *inMemoryRepository) InsertMail(_ context.Context, m *dbMail, recipients []tPlayerID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.mail[m.id]; ok {
+		return fmt.Errorf("insertMail: mail %s %w", m.id, errAlreadyExists)
+	}
+	stored := *m
+	stored.read = false
+	r.mail[m.id] = inMemoryMail{dbMail: stored}
+	r.inbox[m.id] = make(map[tPlayerID]*inMemoryInboxEntry)
+	for _, recipient := range recipients {
+		r.inbox[m.id][recipient] = &inMemoryInboxEntry{}
+	}
+	return nil
+}
+
+// The recipients see the mail with their read status, the sender sees it as read.
+func (r *inMemoryRepository) GetMail(_ context.Context, id, playerID string) (*dbMail, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	m, ok := r.mail[tMailID(id)]
+	if !ok {
+		return nil, fmt.Errorf("getMail: %w", sql.ErrNoRows)
+	}
+	result := m.dbMail
+	if entry, ok := r.inbox[m.id][tPlayerID(playerID)]; ok && !entry.deleted {
+		result.read = entry.read
+		return &result, nil
+	}
+	if string(m.senderID) == playerID && !m.senderDeleted {
+		result.read = true
+		return &result, nil
+	}
+	return nil, fmt.Errorf("getMail: %w", sql.ErrNoRows)
+}
+
+func (r *inMemoryRepository) ListInbox(_ context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	page := listPage(r.mail, lastID, pageSize, func(m inMemoryMail) bool {
+		entry, ok := r.inbox[m.id][tPlayerID(playerID)]
+		return ok && !entry.deleted
+	})
+	results := make([]*dbMail, len(page))
+	for i, m := range page {
+		result := m.dbMail
+		result.read = r.inbox[m.id][tPlayerID(playerID)].read
+		results[i] = &result
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) ListOutbox(_ context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	page := listPage(r.mail, lastID, pageSize, func(m inMemoryMail) bool {
+		return string(m.senderID) == playerID && !m.senderDeleted
+	})
+	results := make([]*dbMail, len(page))
+	for i, m := range page {
+		result := m.dbMail
+		result.read = true
+		results[i] = &result
+	}
+	return results, nil
+}
+
+func (r *inMemoryRepository) MarkMailRead(_ context.Context, id, playerID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entry, ok := r.inbox[tMailID(id)][tPlayerID(playerID)]
+	if !ok || entry.deleted {
+		return fmt.Errorf("markMailRead: %w", sql.ErrNoRows)
+	}
+	entry.read = true
+	return nil
+}
+
+func (r *inMemoryRepository) DeleteMail(_ context.Context, id, playerID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	found := false
+	if entry, ok := r.inbox[tMailID(id)][tPlayerID(playerID)]; ok {
+		entry.deleted = true
+		found = true
+	}
+	if m, ok := r.mail[tMailID(id)]; ok && string(m.senderID) == playerID {
+		m.senderDeleted = true
+		r.mail[m.id] = m
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("deleteMail: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *inMemoryRepository) ListConfigVersions(_ context.Context) ([]*dbConfigVersion, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	versions := append(make([]dbConfigVersion, 0, len(r.configVersions)), r.configVersions...)
+	sort.Slice(versions, func(i, j int) bool { return versions[i].version < versions[j].version })
+	return toPointers(versions), nil
+}
+
+func (r *inMemoryRepository) InsertConfigVersion(_ context.Context, v *dbConfigVersion) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, stored := range r.configVersions {
+		if stored.version == v.version {
+			return fmt.Errorf("insertConfigVersion: version %d already exists", v.version)
+		}
+	}
+	r.configVersions = append(r.configVersions, *v)
+	return nil
+}
@@ -1,11 +1,35 @@
 package internal
 
+import "sort"
+
 type inMemoryStorage struct {
 	cityList              map[tCityID]*city
 	cityByCoordinates     map[coordinates]*city
+	cityByRegion          map[coordinates]map[tCityID]*city
 	movementList          map[tMovementID]*movement
 	unitQueuesPerCity     map[tCityID]map[tUnitQueueItemID]*unitQueueItem
 	buildingQueuesPerCity map[tCityID]map[tBuildingQueueItemID]*buildingQueueItem
+	researchQueuesPerCity map[tCityID]map[tResearchQueueItemID]*researchQueueItem
+	researchByPlayer      map[tPlayerID]map[tResearchName]struct{}
+	allianceList          map[tAllianceID]*alliance
+	allianceByPlayer      map[tPlayerID]tAllianceID
+	attackPoints          map[tPlayerID]int64
+	defencePoints         map[tPlayerID]int64
+	tradeOfferList        map[tTradeOfferID]*tradeOffer
+	// indexes to look up what belongs to a player or city without going
+	// through the whole world, kept in step by the methods below
+	citiesByPlayer     map[tPlayerID]map[tCityID]*city
+	reinforcedCities   map[tPlayerID]map[tCityID]*city
+	movementsByPlayer  map[tPlayerID]map[tMovementID]*movement
+	transportsByOrigin map[tCityID]map[tMovementID]*movement
+	tradeOffersByCity  map[tCityID]map[tTradeOfferID]*tradeOffer
+	// when the beginner protection of a player ends, recorded when they settle
+	// their first city ever and kept after they lose it
+	protectionByPlayer map[tPlayerID]tSec
+	// how many config versions had their villages spawned, and the villages
+	// with a regrowth already scheduled
+	spawnedVillageVersions int
+	regrowingVillages      map[tCityID]struct{}
 }
 
 type coordinates struct {
@@ -16,9 +40,25 @@ type coordinates struct {
 func (m *inMemoryStorage) clear() {
 	m.cityList = make(map[tCityID]*city)
 	m.cityByCoordinates = make(map[coordinates]*city)
+	m.cityByRegion = make(map[coordinates]map[tCityID]*city)
 	m.movementList = make(map[tMovementID]*movement)
 	m.unitQueuesPerCity = make(map[tCityID]map[tUnitQueueItemID]*unitQueueItem)
 	m.buildingQueuesPerCity = make(map[tCityID]map[tBuildingQueueItemID]*buildingQueueItem)
+	m.researchQueuesPerCity = make(map[tCityID]map[tResearchQueueItemID]*researchQueueItem)
+	m.researchByPlayer = make(map[tPlayerID]map[tResearchName]struct{})
+	m.allianceList = make(map[tAllianceID]*alliance)
+	m.allianceByPlayer = make(map[tPlayerID]tAllianceID)
+	m.attackPoints = make(map[tPlayerID]int64)
+	m.defencePoints = make(map[tPlayerID]int64)
+	m.tradeOfferList = make(map[tTradeOfferID]*tradeOffer)
+	m.citiesByPlayer = make(map[tPlayerID]map[tCityID]*city)
+	m.reinforcedCities = make(map[tPlayerID]map[tCityID]*city)
+	m.movementsByPlayer = make(map[tPlayerID]map[tMovementID]*movement)
+	m.transportsByOrigin = make(map[tCityID]map[tMovementID]*movement)
+	m.tradeOffersByCity = make(map[tCityID]map[tTradeOfferID]*tradeOffer)
+	m.protectionByPlayer = make(map[tPlayerID]tSec)
+	m.spawnedVillageVersions = 0
+	m.regrowingVillages = make(map[tCityID]struct{})
 }
 
 func (m *inMemoryStorage) getCityByLocation(x, y tCoordinate) *city {
@@ -28,12 +68,313 @@ func (m *inMemoryStorage) getCityByLocation(x, y tCoordinate) *city {
 func (m *inMemoryStorage) createCity(cityID tCityID, c *city) {
 	m.cityList[cityID] = c
 	m.cityByCoordinates[coordinates{x: c.locationX, y: c.locationY}] = c
+	region := regionOf(c.locationX, c.locationY)
+	if _, ok := m.cityByRegion[region]; !ok {
+		m.cityByRegion[region] = make(map[tCityID]*city)
+	}
+	m.cityByRegion[region][cityID] = c
+	addToIndex(m.citiesByPlayer, c.playerID, cityID, c)
+	for playerID := range c.reinforcements {
+		addToIndex(m.reinforcedCities, playerID, cityID, c)
+	}
 }
 
 func (m *inMemoryStorage) deleteCity(cityID tCityID) {
 	c := m.cityList[cityID]
 	delete(m.cityByCoordinates, coordinates{x: c.locationX, y: c.locationY})
+	region := regionOf(c.locationX, c.locationY)
+	delete(m.cityByRegion[region], cityID)
+	if len(m.cityByRegion[region]) == 0 {
+		delete(m.cityByRegion, region)
+	}
+	deleteFromIndex(m.citiesByPlayer, c.playerID, cityID)
+	for playerID := range c.reinforcements {
+		deleteFromIndex(m.reinforcedCities, playerID, cityID)
+	}
 	delete(m.cityList, cityID)
 	delete(m.buildingQueuesPerCity, cityID)
 	delete(m.unitQueuesPerCity, cityID)
+	delete(m.researchQueuesPerCity, cityID)
+}
+
+// setCityOwner hands a city over to another player.
+func (m *inMemoryStorage) setCityOwner(c *city, playerID tPlayerID) {
+	deleteFromIndex(m.citiesByPlayer, c.playerID, c.id)
+	c.playerID = playerID
+	addToIndex(m.citiesByPlayer, playerID, c.id, c)
+}
+
+// stationedUnits are the units of a player in a city, own or reinforcing it.
+func (m *inMemoryStorage) stationedUnits(c *city, playerID tPlayerID) tUnitsCount {
+	if playerID == c.playerID {
+		return c.unitCount
+	}
+	if _, ok := c.reinforcements[playerID]; !ok {
+		c.reinforcements[playerID] = make(tUnitsCount)
+		addToIndex(m.reinforcedCities, playerID, c.id, c)
+	}
+	return c.reinforcements[playerID]
+}
+
+// withdrawReinforcements takes the units of an ally out of a city.
+func (m *inMemoryStorage) withdrawReinforcements(c *city, playerID tPlayerID) tUnitsCount {
+	unitCount := c.reinforcements[playerID]
+	delete(c.reinforcements, playerID)
+	deleteFromIndex(m.reinforcedCities, playerID, c.id)
+	return unitCount
+}
+
+// closestCity is the city of the player nearest to a location, if any is left.
+// Ties go to the lowest city ID, so that replays pick the same one.
+func (m *inMemoryStorage) closestCity(playerID tPlayerID, x, y tCoordinate) *city {
+	var closest *city
+	var closestDistance int64
+	for _, c := range m.citiesByPlayer[playerID] {
+		dx, dy := int64(c.locationX)-int64(x), int64(c.locationY)-int64(y)
+		distance := dx*dx + dy*dy
+		if closest == nil || distance < closestDistance || (distance == closestDistance && c.id < closest.id) {
+			closest, closestDistance = c, distance
+		}
+	}
+	return closest
+}
+
+func (m *inMemoryStorage) addMovement(mv *movement) {
+	m.movementList[mv.id] = mv
+	addToIndex(m.movementsByPlayer, mv.playerID, mv.id, mv)
+	if mv.movementType == transportMovement {
+		addToIndex(m.transportsByOrigin, mv.originID, mv.id, mv)
+	}
+}
+
+func (m *inMemoryStorage) deleteMovement(movementID tMovementID) {
+	mv, ok := m.movementList[movementID]
+	if !ok {
+		return
+	}
+	deleteFromIndex(m.movementsByPlayer, mv.playerID, movementID)
+	deleteFromIndex(m.transportsByOrigin, mv.originID, movementID)
+	delete(m.movementList, movementID)
+}
+
+// setMovementOrigin is where a movement comes from once it turns around.
+func (m *inMemoryStorage) setMovementOrigin(mv *movement, originID tCityID) {
+	deleteFromIndex(m.transportsByOrigin, mv.originID, mv.id)
+	mv.originID = originID
+	if mv.movementType == transportMovement {
+		addToIndex(m.transportsByOrigin, originID, mv.id, mv)
+	}
+}
+
+func (m *inMemoryStorage) addTradeOffer(o *tradeOffer) {
+	m.tradeOfferList[o.id] = o
+	addToIndex(m.tradeOffersByCity, o.cityID, o.id, o)
+}
+
+func (m *inMemoryStorage) deleteTradeOffer(tradeOfferID tTradeOfferID) {
+	o, ok := m.tradeOfferList[tradeOfferID]
+	if !ok {
+		return
+	}
+	deleteFromIndex(m.tradeOffersByCity, o.cityID, tradeOfferID)
+	delete(m.tradeOfferList, tradeOfferID)
+}
+
+func addToIndex[K, ID comparable, V any](index map[K]map[ID]V, key K, id ID, v V) {
+	if _, ok := index[key]; !ok {
+		index[key] = make(map[ID]V)
+	}
+	index[key][id] = v
+}
+
+func deleteFromIndex[K, ID comparable, V any](index map[K]map[ID]V, key K, id ID) {
+	delete(index[key], id)
+	if len(index[key]) == 0 {
+		delete(index, key)
+	}
+}
+
+// The map is split in square regions of regionSize, indexing the cities by
+// region allows to look up an area without going through every city.
+const regionSize = 16
+
+// regionOf is the region a location is in, regions are numbered from the
+// origin towards both directions.
+func regionOf(x, y tCoordinate) coordinates {
+	floorDiv := func(a tCoordinate) tCoordinate {
+		if a < 0 {
+			return (a+1)/regionSize - 1
+		}
+		return a / regionSize
+	}
+	return coordinates{x: floorDiv(x), y: floorDiv(y)}
+}
+
+// citiesWithin are the cities within the bounds, inclusive, ordered by their
+// location row by row.
+func (m *inMemoryStorage) citiesWithin(x1, y1, x2, y2 tCoordinate) []*city {
+	from, to := regionOf(x1, y1), regionOf(x2, y2)
+	cities := make([]*city, 0)
+	for ry := from.y; ry <= to.y; ry++ {
+		for rx := from.x; rx <= to.x; rx++ {
+			for _, c := range m.cityByRegion[coordinates{x: rx, y: ry}] {
+				if c.locationX >= x1 && c.locationX <= x2 && c.locationY >= y1 && c.locationY <= y2 {
+					cities = append(cities, c)
+				}
+			}
+		}
+	}
+	sort.Slice(cities, func(i, j int) bool {
+		if cities[i].locationY != cities[j].locationY {
+			return cities[i].locationY < cities[j].locationY
+		}
+		return cities[i].locationX < cities[j].locationX
+	})
+	return cities
+}
+
+func (m *inMemoryStorage) areAllies(playerA, playerB tPlayerID) bool {
+	allianceA, ok := m.allianceByPlayer[playerA]
+	if !ok {
+		return false
+	}
+	return allianceA == m.allianceByPlayer[playerB]
+}
+
+// playerProtectedUntil is the beginner protection the cities of a player
+// share, not found when the player never settled a city.
+func (m *inMemoryStorage) playerProtectedUntil(playerID tPlayerID) (tSec, bool) {
+	protectedUntil, ok := m.protectionByPlayer[playerID]
+	return protectedUntil, ok
+}
+
+// startProtection records the beginner protection of a player, it is only
+// given once so losing every city does not earn a new one.
+func (m *inMemoryStorage) startProtection(playerID tPlayerID, protectedUntil tSec) {
+	if _, ok := m.protectionByPlayer[playerID]; ok {
+		return
+	}
+	m.protectionByPlayer[playerID] = protectedUntil
+}
+
+// endProtection cuts the beginner protection of every city of a player short,
+// it returns the cities that were still protected.
+func (m *inMemoryStorage) endProtection(playerID tPlayerID, epoch tSec) []tCityID {
+	if protectedUntil, ok := m.protectionByPlayer[playerID]; ok && protectedUntil > epoch {
+		m.protectionByPlayer[playerID] = epoch
+	}
+	ended := make([]tCityID, 0)
+	for cityID, c := range m.citiesByPlayer[playerID] {
+		if c.protectedUntil <= epoch {
+			continue
+		}
+		c.protectedUntil = epoch
+		ended = append(ended, cityID)
+	}
+	return ended
+}
+
+// merchantsInUse are the resources the merchants of a city are busy with, those
+// its transports carry away and those held by its open trade offers.
+func (m *inMemoryStorage) merchantsInUse(cityID tCityID) tResourceCount {
+	var inUse tResourceCount
+	for _, mv := range m.transportsByOrigin[cityID] {
+		for _, resourceCount := range mv.resourceCount {
+			inUse += resourceCount
+		}
+	}
+	for _, o := range m.tradeOffersByCity[cityID] {
+		for _, resourceCount := range o.offered {
+			inUse += resourceCount
+		}
+	}
+	return inUse
+}
+
+func (m *inMemoryStorage) joinAlliance(allianceID tAllianceID, playerID tPlayerID) {
+	a := m.allianceList[allianceID]
+	delete(a.invites, playerID)
+	a.members[playerID] = struct{}{}
+	m.allianceByPlayer[playerID] = allianceID
+}
+
+func (m *inMemoryStorage) leaveAlliance(allianceID tAllianceID, playerID tPlayerID) {
+	a := m.allianceList[allianceID]
+	delete(a.members, playerID)
+	delete(m.allianceByPlayer, playerID)
+}
+
+func (m *inMemoryStorage) hasResearched(playerID tPlayerID, researchName tResearchName) bool {
+	_, ok := m.researchByPlayer[playerID][researchName]
+	return ok
+}
+
+func (m *inMemoryStorage) isResearchQueued(playerID tPlayerID, researchName tResearchName) bool {
+	for _, researchQ := range m.researchQueuesPerCity {
+		for _, item := range researchQ {
+			if item.playerID == playerID && item.researchName == researchName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Units (or buildings) that are not unlocked by any research are always available.
+func (m *inMemoryStorage) isUnlocked(playerID tPlayerID, unlockedBy []tResearchName) bool {
+	if len(unlockedBy) == 0 {
+		return true
+	}
+	for _, researchName := range unlockedBy {
+		if m.hasResearched(playerID, researchName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *inMemoryStorage) statMultiplier(cfg *Config, playerID tPlayerID, statName tUnitStatName) float64 {
+	multiplier := 1.0
+	for researchName := range m.researchByPlayer[playerID] {
+		if statMultiplier, ok := cfg.Research[researchName].StatMultipliers[statName]; ok {
+			multiplier *= statMultiplier
+		}
+	}
+	return multiplier
+}
+
+const (
+	scorePerCity          = 100
+	scorePerBuildingLevel = 10
+)
+
+// The score of a player reflects the present state: the cities owned, their
+// building levels and the combat power of every unit, stationed or moving,
+// even when stationed in the cities of allies.
+func (m *inMemoryStorage) playerScore(cfg *Config, playerID tPlayerID) int64 {
+	var score int64
+	for _, c := range m.reinforcedCities[playerID] {
+		score += cfg.unitsPower(c.reinforcements[playerID])
+	}
+	for _, c := range m.citiesByPlayer[playerID] {
+		score += scorePerCity
+		for _, level := range c.buildingsLevel {
+			score += scorePerBuildingLevel * int64(level)
+		}
+		score += cfg.unitsPower(c.unitCount)
+	}
+	for _, mv := range m.movementsByPlayer[playerID] {
+		score += cfg.unitsPower(mv.unitCount)
+	}
+	return score
+}
+
+func (cfg *Config) unitsPower(unitCount tUnitsCount) int64 {
+	var power int64
+	for unitName, count := range unitCount {
+		for _, statValue := range cfg.Units[unitName].CombatStats {
+			power += int64(statValue) * int64(count)
+		}
+	}
+	return power
 }
@@ -1 +1,100 @@
 package internal
+
+import "testing"
+
+func TestRegionOf(t *testing.T) {
+	testcases := []struct {
+		x, y tCoordinate
+		want coordinates
+	}{
+		{x: 0, y: 0, want: coordinates{x: 0, y: 0}},
+		{x: 15, y: 16, want: coordinates{x: 0, y: 1}},
+		{x: -1, y: -16, want: coordinates{x: -1, y: -1}},
+		{x: -17, y: 31, want: coordinates{x: -2, y: 1}},
+	}
+	for _, testcase := range testcases {
+		mustEqual(t, regionOf(testcase.x, testcase.y), testcase.want)
+	}
+}
+
+func TestCitiesWithin(t *testing.T) {
+	m := &inMemoryStorage{}
+	m.clear()
+	for _, c := range []*city{
+		{id: "c1", locationX: 0, locationY: 0},
+		{id: "c2", locationX: -1, locationY: 0},
+		{id: "c3", locationX: 16, locationY: -20},
+		{id: "c4", locationX: 40, locationY: 40},
+	} {
+		m.createCity(c.id, c)
+	}
+	ids := func(cities []*city) []tCityID {
+		result := make([]tCityID, len(cities))
+		for i, c := range cities {
+			result[i] = c.id
+		}
+		return result
+	}
+
+	mustEqual(t, ids(m.citiesWithin(-1, -20, 16, 0)), []tCityID{"c3", "c2", "c1"})
+	mustEqual(t, ids(m.citiesWithin(0, 0, 39, 39)), []tCityID{"c1"})
+	mustEqual(t, ids(m.citiesWithin(-100, -100, 100, 100)), []tCityID{"c3", "c2", "c1", "c4"})
+
+	m.deleteCity("c1")
+	mustEqual(t, ids(m.citiesWithin(0, 0, 39, 39)), []tCityID{})
+	_, ok := m.cityByRegion[coordinates{x: 0, y: 0}]
+	mustEqual(t, ok, false)
+}
+
+func TestPlayerScore(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	m := &inMemoryStorage{}
+	m.clear()
+	m.createCity("c1", &city{id: "c1", playerID: "p1", buildingsLevel: tBuildingsLevel{"mines": 2, "barracks": 1}, unitCount: tUnitsCount{"stickmen": 3}})
+	m.createCity("c2", &city{id: "c2", playerID: "p2", locationX: 1, buildingsLevel: tBuildingsLevel{"mines": 5}, unitCount: tUnitsCount{"stickmen": 7}, reinforcements: map[tPlayerID]tUnitsCount{"p1": {"swordsmen": 2}}})
+	m.addMovement(&movement{id: "m1", playerID: "p1", originID: "c1", unitCount: tUnitsCount{"stickmen": 1}})
+	m.addMovement(&movement{id: "m2", playerID: "p2", originID: "c2", unitCount: tUnitsCount{"stickmen": 100}})
+
+	// the cities owned and their buildings, and every unit of the player wherever it is
+	want := scorePerCity + 3*scorePerBuildingLevel + cfg.unitsPower(tUnitsCount{"stickmen": 4, "swordsmen": 2})
+	mustEqual(t, m.playerScore(cfg, "p1"), want)
+	mustEqual(t, m.playerScore(cfg, "p2"), scorePerCity+5*scorePerBuildingLevel+cfg.unitsPower(tUnitsCount{"stickmen": 107}))
+	mustEqual(t, m.playerScore(cfg, "p3"), int64(0))
+
+	// units are scored by the config in effect
+	stickmenPower := cfg.unitsPower(tUnitsCount{"stickmen": 4})
+	cfg.Units["stickmen"] = unitSpecs{CombatStats: map[tUnitStatName]tUnitStatPower{"pierce": 100}}
+	mustEqual(t, m.playerScore(cfg, "p1"), want-stickmenPower+4*100)
+}
+
+func TestIndexesFollowTheWorld(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	m := &inMemoryStorage{}
+	m.clear()
+	m.createCity("c1", &city{id: "c1", playerID: "p1", buildingsLevel: make(tBuildingsLevel), unitCount: make(tUnitsCount), reinforcements: make(map[tPlayerID]tUnitsCount)})
+	m.createCity("c2", &city{id: "c2", playerID: "p2", locationX: 1, buildingsLevel: make(tBuildingsLevel), unitCount: make(tUnitsCount), reinforcements: make(map[tPlayerID]tUnitsCount)})
+	m.addMovement(&movement{id: "m1", playerID: "p1", originID: "c1", resourceCount: tResourcesCount{"sticks": 10}, movementType: transportMovement})
+	m.addMovement(&movement{id: "m2", playerID: "p1", originID: "c1", unitCount: tUnitsCount{"stickmen": 1}, movementType: troopsMovement})
+	m.addTradeOffer(&tradeOffer{id: "o1", playerID: "p1", cityID: "c1", offered: tResourcesCount{"circles": 5}})
+	mustEqual(t, m.merchantsInUse("c1"), tResourceCount(15))
+
+	// a transport turned around keeps its merchants busy where it now comes from
+	m.setMovementOrigin(m.movementList["m1"], "c2")
+	mustEqual(t, m.merchantsInUse("c1"), tResourceCount(5))
+	mustEqual(t, m.merchantsInUse("c2"), tResourceCount(10))
+	m.deleteMovement("m1")
+	m.deleteTradeOffer("o1")
+	mustEqual(t, m.merchantsInUse("c1"), tResourceCount(0))
+	mustEqual(t, m.merchantsInUse("c2"), tResourceCount(0))
+
+	// stationed units count for their owner until the city is gone
+	stationed := m.stationedUnits(m.cityList["c2"], "p1")
+	stationed["stickmen"] = 2
+	m.setCityOwner(m.cityList["c2"], "p1")
+	mustEqual(t, m.playerScore(cfg, "p1"), 2*scorePerCity+cfg.unitsPower(tUnitsCount{"stickmen": 3}))
+	mustEqual(t, m.playerScore(cfg, "p2"), int64(0))
+	m.deleteCity("c2")
+	m.deleteMovement("m2")
+	mustEqual(t, m.playerScore(cfg, "p1"), int64(scorePerCity))
+	mustEqual(t, len(m.reinforcedCities)+len(m.movementsByPlayer)+len(m.transportsByOrigin)+len(m.tradeOffersByCity), 0)
+}
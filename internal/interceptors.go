@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -17,6 +18,11 @@ const (
 	MovementIDKey          ContextKey = "movementID"
 	UnitQueueItemIDKey     ContextKey = "unitQueueItemID"
 	BuildingQueueItemIDKey ContextKey = "buildingQueueItemID"
+	AllianceIDKey          ContextKey = "allianceID"
+	MemberIDKey            ContextKey = "memberID"
+	MailIDKey              ContextKey = "mailID"
+	LeaderboardKindKey     ContextKey = "leaderboardKind"
+	TradeOfferIDKey        ContextKey = "tradeOfferID"
 
 	LastIDKey   ContextKey = "lastID"
 	PageSizeKey ContextKey = "pageSize"
@@ -36,13 +42,25 @@ const (
 	CityID     PathParameterKey = "cityid"
 	ItemID     PathParameterKey = "itemid"
 	MovementID PathParameterKey = "movementid"
+	AllianceID PathParameterKey = "allianceid"
+	MemberID   PathParameterKey = "memberid"
+	MailID     PathParameterKey = "mailid"
+	Kind       PathParameterKey = "kind"
+	OfferID    PathParameterKey = "offerid"
 
 	LastID         QueryParameterKey = "lastid"
 	PageSize       QueryParameterKey = "pagesize"
 	PlayerID       QueryParameterKey = "playerid"
 	LocationBounds QueryParameterKey = "locationbounds"
+	Near           QueryParameterKey = "near"
+	Radius         QueryParameterKey = "radius"
 	OriginID       QueryParameterKey = "originid"
 	DestinationID  QueryParameterKey = "destinationid"
+	X1             QueryParameterKey = "x1"
+	Y1             QueryParameterKey = "y1"
+	X2             QueryParameterKey = "x2"
+	Y2             QueryParameterKey = "y2"
+	ZoomedOut      QueryParameterKey = "zoomedout"
 )
 
 func WithAuthentication(next http.Handler) http.Handler {
@@ -60,6 +78,21 @@ func WithAuthentication(next http.Handler) http.Handler {
 	})
 }
 
+// WithAdminToken only lets through requests bearing the admin token, admin
+// routes are disabled altogether when no token is configured.
+func WithAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Admin-Token")), []byte(token)) != 1 {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte("forbidden"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func WithCityIDContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cityID := chi.URLParam(r, CityID.String())
@@ -116,6 +149,76 @@ func WithBuildingQueueItemIDContext(next http.Handler) http.Handler {
 	})
 }
 
+func WithAllianceIDContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allianceID := chi.URLParam(r, AllianceID.String())
+		if allianceID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("missing /allianceid/ path parameter"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), AllianceIDKey, allianceID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func WithMemberIDContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		memberID := chi.URLParam(r, MemberID.String())
+		if memberID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("missing /memberid/ path parameter"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), MemberIDKey, memberID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func WithMailIDContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mailID := chi.URLParam(r, MailID.String())
+		if mailID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("missing /mailid/ path parameter"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), MailIDKey, mailID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func WithTradeOfferIDContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tradeOfferID := chi.URLParam(r, OfferID.String())
+		if tradeOfferID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("missing /offerid/ path parameter"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), TradeOfferIDKey, tradeOfferID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func WithLeaderboardKindContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := chi.URLParam(r, Kind.String())
+		if kind == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("missing /kind/ path parameter"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), LeaderboardKindKey, kind)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func WithPagination(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		lastID := r.URL.Query().Get(LastID.String())
@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The migrations are named <version>_<name>.<up|down>.sql, every new table or
+// view comes with its own version so that existing worlds can be upgraded.
+// Each storage driver has its own directory with the same versions.
+//
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+type migration struct {
+	version int64
+	name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus tells whether a migration was already applied to a database.
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+func loadMigrations(files fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(fileName, ".sql"), ".")
+		rawVersion, name, okName := strings.Cut(base, "_")
+		if !ok || !okName || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("unexpected migration file %s", fileName)
+		}
+		version, err := strconv.ParseInt(rawVersion, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected migration version %s: %w", fileName, err)
+		}
+		content, err := fs.ReadFile(files, path.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		}
+		if m.name != name {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.name, name)
+		}
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up", m.version, m.name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+func (r *StickerioRepository) appliedMigrations(ctx context.Context) (map[int64]bool, error) {
+	const createSchemaMigrationsQuery = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+version int primary key,
+name text
+)
+`
+	const listSchemaMigrationsQuery = `
+SELECT version FROM schema_migrations
+`
+	_, err := r.db.ExecContext(ctx, createSchemaMigrationsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("createSchemaMigrationsQuery failed: %w", err)
+	}
+	rows, err := r.db.QueryContext(ctx, listSchemaMigrationsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("listSchemaMigrationsQuery failed: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+	return applied, nil
+}
+
+// runMigration executes a migration script and records it in a single
+// transaction, a failed migration leaves no trace.
+func (r *StickerioRepository) runMigration(ctx context.Context, script, record string, args ...any) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, script)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, record, args...)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MigrateUp applies every pending migration in order and returns how many
+// were applied.
+func (r *StickerioRepository) MigrateUp(ctx context.Context) (int, error) {
+	const insertSchemaMigrationQuery = `
+INSERT INTO schema_migrations(version, name) VALUES ($1, $2)
+`
+	migrations, err := loadMigrations(migrationFiles, r.driver.migrationsDir)
+	if err != nil {
+		return 0, err
+	}
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		err = r.runMigration(ctx, m.up, insertSchemaMigrationQuery, m.version, m.name)
+		if err != nil {
+			return count, fmt.Errorf("migration %d_%s up: %w", m.version, m.name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// MigrateDown reverts the latest steps applied migrations, newest first, and
+// returns how many were reverted.
+func (r *StickerioRepository) MigrateDown(ctx context.Context, steps int) (int, error) {
+	const deleteSchemaMigrationQuery = `
+DELETE FROM schema_migrations WHERE version=$1
+`
+	migrations, err := loadMigrations(migrationFiles, r.driver.migrationsDir)
+	if err != nil {
+		return 0, err
+	}
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+		m := migrations[i]
+		if !applied[m.version] {
+			continue
+		}
+		if m.down == "" {
+			return count, fmt.Errorf("migration %d_%s has no down", m.version, m.name)
+		}
+		err = r.runMigration(ctx, m.down, deleteSchemaMigrationQuery, m.version)
+		if err != nil {
+			return count, fmt.Errorf("migration %d_%s down: %w", m.version, m.name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *StickerioRepository) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations(migrationFiles, r.driver.migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		status[i] = MigrationStatus{Version: m.version, Name: m.name, Applied: applied[m.version]}
+	}
+	return status, nil
+}
@@ -0,0 +1,6 @@
+create table event_source (
+    id text primary key,
+    event_name text,
+    epoch bigint,
+    payload text -- json serialization of the events
+);
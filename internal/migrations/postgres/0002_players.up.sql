@@ -0,0 +1,9 @@
+create table players (
+    id text primary key,
+    nickname text,
+    score bigint,
+    attack_score bigint,
+    defence_score bigint,
+    alliance_id text,
+    research text -- json serialization of completed research names
+);
@@ -0,0 +1,11 @@
+create table cities_view (
+    id text primary key,
+    city_name text,
+    player_id text,
+    location_x int,
+    location_y int,
+    b_level jsonb, -- json serialization of buildingID: level
+    r_base jsonb, -- json serialization of resourceID: baseQuantity
+    r_epoch bigint,
+    u_count jsonb -- json serialization of unitID: count
+);
@@ -0,0 +1,12 @@
+create table movements_view (
+    id text primary key,
+    player_id text,
+    origin_id text,
+    destination_id text,
+    destination_x int,
+    destination_y int,
+    departure_epoch bigint,
+    speed double precision,
+    r_count jsonb, -- json serialization of resourceID: count
+    u_count jsonb -- json serialization of unitID: count
+);
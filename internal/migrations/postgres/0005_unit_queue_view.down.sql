@@ -0,0 +1 @@
+drop table if exists unit_queue_view;
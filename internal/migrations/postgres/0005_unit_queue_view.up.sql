@@ -0,0 +1,9 @@
+create table unit_queue_view (
+    id text primary key,
+    city_id text,
+    player_id text,
+    queued_epoch bigint,
+    duration_s bigint,
+    unit_count bigint,
+    unit_type text
+);
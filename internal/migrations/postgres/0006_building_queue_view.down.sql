@@ -0,0 +1 @@
+drop table if exists building_queue_view;
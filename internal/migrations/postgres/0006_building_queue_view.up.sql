@@ -0,0 +1,9 @@
+create table building_queue_view (
+    id text primary key,
+    city_id text,
+    player_id text,
+    queued_epoch bigint,
+    duration_s bigint,
+    target_level bigint,
+    target_building text
+);
@@ -0,0 +1 @@
+drop table if exists research_queue_view;
@@ -0,0 +1,8 @@
+create table research_queue_view (
+    id text primary key,
+    city_id text,
+    player_id text,
+    queued_epoch bigint,
+    duration_s bigint,
+    research_name text
+);
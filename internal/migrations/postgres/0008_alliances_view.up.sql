@@ -0,0 +1,6 @@
+create table alliances_view (
+    id text primary key,
+    alliance_name text,
+    leader_id text,
+    invites text -- json serialization of invited player IDs
+);
@@ -0,0 +1,2 @@
+drop table if exists mail_inbox;
+drop table if exists mail_messages;
@@ -0,0 +1,18 @@
+create table mail_messages (
+    id text primary key,
+    sender_id text, -- empty for system generated mail
+    recipient_id text,
+    alliance_id text,
+    subject text,
+    content text,
+    sent_epoch bigint,
+    sender_deleted int
+);
+
+create table mail_inbox (
+    mail_id text,
+    player_id text,
+    read int,
+    deleted int,
+    primary key (mail_id, player_id)
+);
@@ -0,0 +1,6 @@
+create table config_versions (
+    version bigint primary key,
+    effective_from bigint,
+    source_hash text, -- sha256 of the activated config file
+    config text -- json serialization of the parsed config
+);
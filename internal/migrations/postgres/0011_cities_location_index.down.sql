@@ -0,0 +1 @@
+drop index if exists cities_view_location;
@@ -0,0 +1 @@
+create index if not exists cities_view_location on cities_view(location_x, location_y);
@@ -0,0 +1,2 @@
+-- the epoch until which a city cannot be attacked, zero when it never was protected
+alter table cities_view add column protected_until bigint not null default 0;
@@ -0,0 +1,8 @@
+create table trade_offers_view (
+    id text primary key,
+    player_id text,
+    city_id text,
+    offered jsonb, -- json serialization of resourceID: count
+    requested jsonb, -- json serialization of resourceID: count
+    created_epoch bigint
+);
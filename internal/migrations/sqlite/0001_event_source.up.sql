@@ -0,0 +1,7 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists event_source (
+    id text primary key,
+    event_name text,
+    epoch int,
+    payload text -- json serialization of the events
+);
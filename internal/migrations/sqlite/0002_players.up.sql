@@ -0,0 +1,10 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists players (
+    id text primary key,
+    nickname text,
+    score int,
+    attack_score int,
+    defence_score int,
+    alliance_id text,
+    research text -- json serialization of completed research names
+);
@@ -0,0 +1 @@
+drop table if exists cities_view;
@@ -0,0 +1,12 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists cities_view (
+    id text primary key,
+    city_name text,
+    player_id text,
+    location_x int,
+    location_y int,
+    b_level text, -- json serialization of buildingID: level
+    r_base text, -- json serialization of resourceID: baseQuantity
+    r_epoch int,
+    u_count text -- json serialization of unitID: count
+);
@@ -0,0 +1 @@
+drop table if exists movements_view;
@@ -0,0 +1,13 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists movements_view (
+    id text primary key,
+    player_id text,
+    origin_id text,
+    destination_id text,
+    destination_x int,
+    destination_y int,
+    departure_epoch int,
+    speed real,
+    r_count text, -- json serialization of resourceID: count
+    u_count text -- json serialization of unitID: count
+);
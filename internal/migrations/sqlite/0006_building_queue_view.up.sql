@@ -0,0 +1,10 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists building_queue_view (
+    id text primary key,
+    city_id text,
+    player_id text,
+    queued_epoch int,
+    duration_s int,
+    target_level int,
+    target_building text
+);
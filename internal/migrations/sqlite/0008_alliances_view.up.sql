@@ -0,0 +1,7 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists alliances_view (
+    id text primary key,
+    alliance_name text,
+    leader_id text,
+    invites text -- json serialization of invited player IDs
+);
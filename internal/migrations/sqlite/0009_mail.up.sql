@@ -0,0 +1,19 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists mail_messages (
+    id text primary key,
+    sender_id text, -- empty for system generated mail
+    recipient_id text,
+    alliance_id text,
+    subject text,
+    content text,
+    sent_epoch int,
+    sender_deleted int
+);
+
+create table if not exists mail_inbox (
+    mail_id text,
+    player_id text,
+    read int,
+    deleted int,
+    primary key (mail_id, player_id)
+);
@@ -0,0 +1,7 @@
+-- tables that predate the migrations might already exist, hence if not exists
+create table if not exists config_versions (
+    version int primary key,
+    effective_from int,
+    source_hash text, -- sha256 of the activated config file
+    config text -- json serialization of the parsed config
+);
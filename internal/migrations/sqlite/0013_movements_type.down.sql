@@ -0,0 +1 @@
+alter table movements_view drop column movement_type;
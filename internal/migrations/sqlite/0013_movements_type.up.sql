@@ -0,0 +1,2 @@
+-- troops or transport, the movements that predate transports are all troops
+alter table movements_view add column movement_type text not null default 'troops';
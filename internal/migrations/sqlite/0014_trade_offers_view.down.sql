@@ -0,0 +1 @@
+drop table if exists trade_offers_view;
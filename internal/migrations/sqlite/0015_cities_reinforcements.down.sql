@@ -0,0 +1 @@
+alter table cities_view drop column reinforcements;
@@ -0,0 +1,2 @@
+-- the units allies keep stationed in a city, json serialization of playerID: unitID: count
+alter table cities_view add column reinforcements text not null default '{}';
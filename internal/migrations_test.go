@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	migrations, err := loadMigrations(migrationFiles, sqliteDriver.migrationsDir)
+	mustNoErr(t, err)
+	r := NewStickerioRepository("file:" + t.TempDir() + "/stickerio.db")
+	t.Cleanup(func() { r.db.Close() })
+
+	applied, err := r.MigrateUp(ctx)
+	mustNoErr(t, err)
+	mustEqual(t, applied, len(migrations))
+	applied, err = r.MigrateUp(ctx)
+	mustNoErr(t, err)
+	mustEqual(t, applied, 0)
+
+	// the migrations since the config versions are reverted and applied again
+	steps := 0
+	for _, m := range migrations {
+		if m.version >= 10 {
+			steps++
+		}
+	}
+	reverted, err := r.MigrateDown(ctx, steps)
+	mustNoErr(t, err)
+	mustEqual(t, reverted, steps)
+	status, err := r.MigrationStatus(ctx)
+	mustNoErr(t, err)
+	mustEqual(t, status[len(status)-steps].Applied, false)
+	mustEqual(t, status[len(status)-steps-1].Applied, true)
+	_, err = r.ListConfigVersions(ctx)
+	if err == nil {
+		t.Fatal("expected the config versions table to be dropped")
+	}
+	applied, err = r.MigrateUp(ctx)
+	mustNoErr(t, err)
+	mustEqual(t, applied, steps)
+	_, err = r.ListConfigVersions(ctx)
+	mustNoErr(t, err)
+
+	reverted, err = r.MigrateDown(ctx, len(migrations)+1)
+	mustNoErr(t, err)
+	mustEqual(t, reverted, len(migrations))
+	_, err = r.ListEvents(ctx, 0)
+	if err == nil {
+		t.Fatal("expected the event source table to be dropped")
+	}
+}
+
+func TestMigrationsAdoptExistingTables(t *testing.T) {
+	ctx := context.Background()
+	r := NewStickerioRepository("file:" + t.TempDir() + "/stickerio.db")
+	t.Cleanup(func() { r.db.Close() })
+
+	// worlds created before the migrations already have some of the tables
+	_, err := r.db.Exec(`create table event_source (id text primary key, event_name text, epoch int, payload text)`)
+	mustNoErr(t, err)
+	mustNoErr(t, r.InsertEvent(ctx, &event{id: "a", name: createCityEventName, epoch: 1, payload: "{}"}))
+
+	_, err = r.MigrateUp(ctx)
+	mustNoErr(t, err)
+	events, err := r.ListEvents(ctx, 1)
+	mustNoErr(t, err)
+	mustEqual(t, len(events), 1)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	testcases := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []migration
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"m/0002_b.up.sql":   {Data: []byte("up b")},
+				"m/0001_a.up.sql":   {Data: []byte("up a")},
+				"m/0001_a.down.sql": {Data: []byte("down a")},
+			},
+			want: []migration{{version: 1, name: "a", up: "up a", down: "down a"}, {version: 2, name: "b", up: "up b"}},
+		},
+		{
+			name:    "missing up",
+			files:   fstest.MapFS{"m/0001_a.down.sql": {Data: []byte("down a")}},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected direction",
+			files:   fstest.MapFS{"m/0001_a.sideways.sql": {Data: []byte("a")}},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected version",
+			files:   fstest.MapFS{"m/first_a.up.sql": {Data: []byte("a")}},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"m/0001_a.up.sql":   {Data: []byte("up a")},
+				"m/0001_b.down.sql": {Data: []byte("down b")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := loadMigrations(testcase.files, "m")
+			if testcase.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			mustNoErr(t, err)
+			mustEqual(t, got, testcase.want)
+		})
+	}
+}
+
+func TestMigrationsMatchAcrossDrivers(t *testing.T) {
+	sqliteMigrations, err := loadMigrations(migrationFiles, sqliteDriver.migrationsDir)
+	mustNoErr(t, err)
+	postgresMigrations, err := loadMigrations(migrationFiles, postgresDriver.migrationsDir)
+	mustNoErr(t, err)
+
+	mustEqual(t, len(postgresMigrations), len(sqliteMigrations))
+	for i := range sqliteMigrations {
+		mustEqual(t, postgresMigrations[i].version, sqliteMigrations[i].version)
+		mustEqual(t, postgresMigrations[i].name, sqliteMigrations[i].name)
+	}
+}
@@ -3,33 +3,77 @@ package internal
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
-
-	_ "modernc.org/sqlite"
 )
 
+// NewStickerioRepository opens the database of a world, a postgres:// URL is
+// a PostgreSQL database and anything else a SQLite file.
 func NewStickerioRepository(dataSourceName string) *StickerioRepository {
-	db, err := sql.Open("sqlite", dataSourceName) // TODO: don't use sqlite
+	driver, driverDataSourceName, err := storageDriverFromDSN(dataSourceName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := sql.Open(driver.name, driverDataSourceName)
 	if err != nil {
 		log.Fatal(err)
 	}
 	return &StickerioRepository{
-		db: db,
+		db:     db,
+		driver: driver,
 	}
 }
 
 type StickerioRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	driver storageDriver
+}
+
+var (
+	// thrown when inserting a row that cannot be overwritten, e.g., a mail
+	// with the ID of another one
+	errAlreadyExists = fmt.Errorf("already exists")
+)
+
+// sqlExecer is either the database or a batch of statements in a transaction.
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// txStatements prepares each query once per transaction, a batch of views
+// runs the same few queries over and over. The statements are closed along
+// with the transaction.
+type txStatements struct {
+	tx    *sql.Tx
+	stmts map[string]*sql.Stmt
+}
+
+func (s *txStatements) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, ok := s.stmts[query]
+	if !ok {
+		var err error
+		stmt, err = s.tx.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		s.stmts[query] = stmt
+	}
+	return stmt.ExecContext(ctx, args...)
 }
 
 func (r *StickerioRepository) InsertEvent(ctx context.Context, e *event) error {
+	return insertEvent(ctx, r.db, e)
+}
+
+func insertEvent(ctx context.Context, db sqlExecer, e *event) error {
 	const insertEventQuery = `
 INSERT INTO event_source(id, event_name, epoch, payload) VALUES ($1, $2, $3, $4)
 ON CONFLICT(id) DO NOTHING
 `
-	_, err := r.db.ExecContext(ctx, insertEventQuery, e.id, e.name, e.epoch, e.payload)
+	_, err := db.ExecContext(ctx, insertEventQuery, e.id, e.name, e.epoch, e.payload)
 	if err != nil {
 		return fmt.Errorf("insertEventQuery failed: %w", err)
 	}
@@ -75,6 +119,115 @@ ORDER BY epoch, id
 	return results, nil
 }
 
+// UpsertViews writes every view change in a single transaction, if any of them
+// fails none is written.
+func (r *StickerioRepository) UpsertViews(ctx context.Context, v *dbViews) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	db := &txStatements{tx: tx, stmts: make(map[string]*sql.Stmt)}
+
+	for _, e := range v.events {
+		if err := insertEvent(ctx, db, e); err != nil {
+			return err
+		}
+	}
+	for _, c := range v.cities {
+		if err := upsertCity(ctx, db, c); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedCities {
+		if err := deleteCity(ctx, db, string(id)); err != nil {
+			return err
+		}
+		if err := deleteBuildingQueueItemsFromCity(ctx, db, string(id)); err != nil {
+			return err
+		}
+		if err := deleteUnitQueueItemsFromCity(ctx, db, string(id)); err != nil {
+			return err
+		}
+		if err := deleteResearchQueueItemsFromCity(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, m := range v.movements {
+		if err := upsertMovement(ctx, db, m); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedMovements {
+		if err := deleteMovement(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, item := range v.unitQueueItems {
+		if err := upsertUnitQueueItem(ctx, db, item); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedUnitQueueItems {
+		if err := deleteUnitQueueItem(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, item := range v.buildingQueueItems {
+		if err := upsertBuildingQueueItem(ctx, db, item); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedBuildingQueueItems {
+		if err := deleteBuildingQueueItem(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, item := range v.researchQueueItems {
+		if err := upsertResearchQueueItem(ctx, db, item); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedResearchQueueItems {
+		if err := deleteResearchQueueItem(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, a := range v.alliances {
+		if err := upsertAlliance(ctx, db, a); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedAlliances {
+		if err := deleteAlliance(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, p := range v.players {
+		if err := upsertPlayer(ctx, db, p); err != nil {
+			return err
+		}
+	}
+	for _, o := range v.tradeOffers {
+		if err := upsertTradeOffer(ctx, db, o); err != nil {
+			return err
+		}
+	}
+	for _, id := range v.deletedTradeOffers {
+		if err := deleteTradeOffer(ctx, db, string(id)); err != nil {
+			return err
+		}
+	}
+	for _, d := range v.mails {
+		// system mail is already delivered when the events are re-synced
+		if err := insertMail(ctx, db, d.mail, d.recipients); err != nil && !errors.Is(err, errAlreadyExists) {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (r *StickerioRepository) GetCity(ctx context.Context, id, playerID string) (*dbCity, error) {
 	const getCityQuery = `
 SELECT
@@ -86,9 +239,17 @@ location_y,
 b_level,
 r_base,
 r_epoch,
-u_count
+u_count,
+protected_until,
+reinforcements
 FROM cities_view
-WHERE id=$1 AND player_id=$2
+WHERE id=$1 AND (
+	player_id=$2 OR player_id IN (
+		SELECT p.id FROM players p
+		JOIN players me ON me.alliance_id=p.alliance_id
+		WHERE me.id=$2 AND me.alliance_id<>''
+	)
+)
 `
 
 	row := r.db.QueryRowContext(ctx, getCityQuery, id, playerID)
@@ -99,10 +260,12 @@ WHERE id=$1 AND player_id=$2
 		&result.playerID,
 		&result.locationX,
 		&result.locationY,
-		&result.buildingsLevel,
-		&result.resourceBase,
+		jsonColumn{&result.buildingsLevel},
+		jsonColumn{&result.resourceBase},
 		&result.resourceEpoch,
-		&result.unitCount,
+		jsonColumn{&result.unitCount},
+		&result.protectedUntil,
+		jsonColumn{&result.reinforcements},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("getCityQuery scan: %w", err)
@@ -122,7 +285,8 @@ id,
 city_name,
 player_id,
 location_x,
-location_y
+location_y,
+protected_until
 FROM cities_view
 WHERE id=$1
 `
@@ -135,6 +299,7 @@ WHERE id=$1
 		&result.playerID,
 		&result.locationX,
 		&result.locationY,
+		&result.protectedUntil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("getCityInfoQuery scan: %w", err)
@@ -188,7 +353,8 @@ id,
 city_name,
 player_id,
 location_x,
-location_y
+location_y,
+protected_until
 FROM cities_view
 %s
 ORDER BY id
@@ -210,6 +376,112 @@ LIMIT $2
 			&result.playerID,
 			&result.locationX,
 			&result.locationY,
+			&result.protectedUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+// ListCityInfoNear lists the cities nearest to a location first, up to radius
+// away unless it is negative. Distances are compared squared so that they are
+// exact, ties are ordered by ID so that the pages are stable. The page goes on
+// from the distance of the last city, which must still exist.
+// NOTE: squared distances overflow the integer columns, they are bigint.
+func (r *StickerioRepository) ListCityInfoNear(ctx context.Context, x, y tCoordinate, radius int, lastID string, pageSize int, filters ...listCityInfoFilterOpt) ([]*dbCity, error) {
+	const lastDistanceQuery = `
+SELECT (CAST(location_x AS BIGINT)-$1)*(CAST(location_x AS BIGINT)-$1)+(CAST(location_y AS BIGINT)-$2)*(CAST(location_y AS BIGINT)-$2)
+FROM cities_view
+WHERE id=$3
+`
+
+	lastDistance := int64(-1)
+	if lastID != "" {
+		err := r.db.QueryRowContext(ctx, lastDistanceQuery, x, y, lastID).Scan(&lastDistance)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: no city %s to list after", errInvalidRequest, lastID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("lastDistanceQuery scan: %w", err)
+		}
+	}
+
+	radiusQuery := ""
+	filtersValues := make([]interface{}, 0, len(filters)+6)
+	filtersValues = append(filtersValues, x, y, lastID, pageSize, lastDistance)
+	if radius >= 0 {
+		// the bounding box of the radius is what the location index can narrow down, it
+		// goes no further than the coordinates do
+		filters = append(filters, withinLocation(
+			max(int(x)-radius, math.MinInt32), max(int(y)-radius, math.MinInt32),
+			min(int(x)+radius, math.MaxInt32), min(int(y)+radius, math.MaxInt32),
+		)...)
+		filtersValues = append(filtersValues, int64(radius)*int64(radius))
+		radiusQuery = "AND distance<=$6"
+	}
+	filtersQuery := ""
+	for i, filter := range filters {
+		q, v := filter()
+		filtersValues = append(filtersValues, v)
+		if i == 0 {
+			filtersQuery += "WHERE "
+		} else {
+			filtersQuery += " AND "
+		}
+		filtersQuery += q + "$" + strconv.Itoa(len(filtersValues))
+	}
+
+	listCityInfoNearQuery := fmt.Sprintf(`
+WITH distances AS (
+	SELECT
+	id,
+	city_name,
+	player_id,
+	location_x,
+	location_y,
+	protected_until,
+	(CAST(location_x AS BIGINT)-$1)*(CAST(location_x AS BIGINT)-$1)+(CAST(location_y AS BIGINT)-$2)*(CAST(location_y AS BIGINT)-$2) AS distance
+	FROM cities_view
+	%s
+)
+SELECT
+id,
+city_name,
+player_id,
+location_x,
+location_y,
+protected_until
+FROM distances
+WHERE (distance>$5 OR (distance=$5 AND id>$3))
+%s
+ORDER BY distance, id
+LIMIT $4
+`, filtersQuery, radiusQuery)
+
+	rows, err := r.db.QueryContext(ctx, listCityInfoNearQuery, filtersValues...)
+	if err != nil {
+		return nil, fmt.Errorf("listCityInfoNearQuery failed: %w", err)
+	}
+
+	results := make([]*dbCity, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbCity{}
+		err := rows.Scan(
+			&result.id,
+			&result.name,
+			&result.playerID,
+			&result.locationX,
+			&result.locationY,
+			&result.protectedUntil,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("rows scan: %w", err)
@@ -225,8 +497,12 @@ LIMIT $2
 }
 
 func (r *StickerioRepository) UpsertCity(ctx context.Context, c *dbCity) error {
+	return upsertCity(ctx, r.db, c)
+}
+
+func upsertCity(ctx context.Context, db sqlExecer, c *dbCity) error {
 	const upsertCityQuery = `
-INSERT INTO city_view(
+INSERT INTO cities_view(
 id,
 city_name,
 player_id,
@@ -235,12 +511,24 @@ location_y,
 b_level,
 r_base,
 r_epoch,
-u_count)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-ON CONFLICT(id) REPLACE
+u_count,
+protected_until,
+reinforcements)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT(id) DO UPDATE SET
+city_name=excluded.city_name,
+player_id=excluded.player_id,
+location_x=excluded.location_x,
+location_y=excluded.location_y,
+b_level=excluded.b_level,
+r_base=excluded.r_base,
+r_epoch=excluded.r_epoch,
+u_count=excluded.u_count,
+protected_until=excluded.protected_until,
+reinforcements=excluded.reinforcements
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		upsertCityQuery,
 		c.id,
@@ -252,6 +540,8 @@ ON CONFLICT(id) REPLACE
 		c.resourceBase,
 		c.resourceEpoch,
 		c.unitCount,
+		c.protectedUntil,
+		c.reinforcements,
 	)
 	if err != nil {
 		return fmt.Errorf("upsertCityQuery failed: %w", err)
@@ -261,12 +551,16 @@ ON CONFLICT(id) REPLACE
 }
 
 func (r *StickerioRepository) DeleteCity(ctx context.Context, cityID string) error {
+	return deleteCity(ctx, r.db, cityID)
+}
+
+func deleteCity(ctx context.Context, db sqlExecer, cityID string) error {
 	const deleteCityQuery = `
-DELETE FROM city_view
+DELETE FROM cities_view
 WHERE id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteCityQuery,
 		cityID,
@@ -290,7 +584,8 @@ destination_y,
 departure_epoch,
 speed,
 r_count,
-u_count
+u_count,
+movement_type
 FROM movements_view
 WHERE id=$1 AND player_id=$2
 `
@@ -306,8 +601,9 @@ WHERE id=$1 AND player_id=$2
 		&result.destinationY,
 		&result.departureEpoch,
 		&result.speed,
-		&result.resourceCount,
-		&result.unitCount,
+		jsonColumn{&result.resourceCount},
+		jsonColumn{&result.unitCount},
+		&result.movementType,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("getMovementQuery scan: %w", err)
@@ -356,7 +652,8 @@ destination_y,
 departure_epoch,
 speed,
 r_count,
-u_count
+u_count,
+movement_type
 FROM movements_view
 %s
 ORDER BY id
@@ -381,8 +678,9 @@ LIMIT $3
 			&result.destinationY,
 			&result.departureEpoch,
 			&result.speed,
-			&result.resourceCount,
-			&result.unitCount,
+			jsonColumn{&result.resourceCount},
+			jsonColumn{&result.unitCount},
+			&result.movementType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("rows scan: %w", err)
@@ -398,6 +696,10 @@ LIMIT $3
 }
 
 func (r *StickerioRepository) UpsertMovement(ctx context.Context, m *dbMovement) error {
+	return upsertMovement(ctx, r.db, m)
+}
+
+func upsertMovement(ctx context.Context, db sqlExecer, m *dbMovement) error {
 	const upsertMovementQuery = `
 INSERT INTO movements_view(
 id,
@@ -409,12 +711,23 @@ destination_y,
 departure_epoch,
 speed,
 r_count,
-u_count)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-ON CONFLICT(id) REPLACE
+u_count,
+movement_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT(id) DO UPDATE SET
+player_id=excluded.player_id,
+origin_id=excluded.origin_id,
+destination_id=excluded.destination_id,
+destination_x=excluded.destination_x,
+destination_y=excluded.destination_y,
+departure_epoch=excluded.departure_epoch,
+speed=excluded.speed,
+r_count=excluded.r_count,
+u_count=excluded.u_count,
+movement_type=excluded.movement_type
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		upsertMovementQuery,
 		m.id,
@@ -427,6 +740,7 @@ ON CONFLICT(id) REPLACE
 		m.speed,
 		m.resourceCount,
 		m.unitCount,
+		m.movementType,
 	)
 	if err != nil {
 		return fmt.Errorf("upsertMovementQuery failed: %w", err)
@@ -436,12 +750,16 @@ ON CONFLICT(id) REPLACE
 }
 
 func (r *StickerioRepository) DeleteMovement(ctx context.Context, movementID string) error {
+	return deleteMovement(ctx, r.db, movementID)
+}
+
+func deleteMovement(ctx context.Context, db sqlExecer, movementID string) error {
 	const deleteMovementQuery = `
 DELETE FROM movements_view
 WHERE id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteMovementQuery,
 		movementID,
@@ -538,6 +856,10 @@ LIMIT $4
 }
 
 func (r *StickerioRepository) UpsertUnitQueueItem(ctx context.Context, m *dbUnitQueueItem) error {
+	return upsertUnitQueueItem(ctx, r.db, m)
+}
+
+func upsertUnitQueueItem(ctx context.Context, db sqlExecer, m *dbUnitQueueItem) error {
 	const upsertUnitQueueItemQuery = `
 INSERT INTO unit_queue_view(
 id,
@@ -548,10 +870,16 @@ duration_s,
 unit_count,
 unit_type)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-ON CONFLICT(id) REPLACE
+ON CONFLICT(id) DO UPDATE SET
+city_id=excluded.city_id,
+player_id=excluded.player_id,
+queued_epoch=excluded.queued_epoch,
+duration_s=excluded.duration_s,
+unit_count=excluded.unit_count,
+unit_type=excluded.unit_type
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		upsertUnitQueueItemQuery,
 		m.id,
@@ -570,12 +898,16 @@ ON CONFLICT(id) REPLACE
 }
 
 func (r *StickerioRepository) DeleteUnitQueueItem(ctx context.Context, unitQueueItemID string) error {
+	return deleteUnitQueueItem(ctx, r.db, unitQueueItemID)
+}
+
+func deleteUnitQueueItem(ctx context.Context, db sqlExecer, unitQueueItemID string) error {
 	const deleteUnitQueueItemQuery = `
 DELETE FROM unit_queue_view
 WHERE id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteUnitQueueItemQuery,
 		unitQueueItemID,
@@ -588,12 +920,16 @@ WHERE id=$1
 }
 
 func (r *StickerioRepository) DeleteUnitQueueItemsFromCity(ctx context.Context, cityID string) error {
+	return deleteUnitQueueItemsFromCity(ctx, r.db, cityID)
+}
+
+func deleteUnitQueueItemsFromCity(ctx context.Context, db sqlExecer, cityID string) error {
 	const deleteUnitQueueItemQuery = `
 DELETE FROM unit_queue_view
 WHERE city_id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteUnitQueueItemQuery,
 		cityID,
@@ -690,6 +1026,10 @@ LIMIT $4
 }
 
 func (r *StickerioRepository) UpsertBuildingQueueItem(ctx context.Context, m *dbBuildingQueueItem) error {
+	return upsertBuildingQueueItem(ctx, r.db, m)
+}
+
+func upsertBuildingQueueItem(ctx context.Context, db sqlExecer, m *dbBuildingQueueItem) error {
 	const upsertBuildingQueueItemQuery = `
 INSERT INTO building_queue_view(
 id,
@@ -700,10 +1040,16 @@ duration_s,
 target_level,
 target_building)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-ON CONFLICT(id) REPLACE
+ON CONFLICT(id) DO UPDATE SET
+city_id=excluded.city_id,
+player_id=excluded.player_id,
+queued_epoch=excluded.queued_epoch,
+duration_s=excluded.duration_s,
+target_level=excluded.target_level,
+target_building=excluded.target_building
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		upsertBuildingQueueItemQuery,
 		m.id,
@@ -722,12 +1068,16 @@ ON CONFLICT(id) REPLACE
 }
 
 func (r *StickerioRepository) DeleteBuildingQueueItem(ctx context.Context, buildingQueueItemID string) error {
+	return deleteBuildingQueueItem(ctx, r.db, buildingQueueItemID)
+}
+
+func deleteBuildingQueueItem(ctx context.Context, db sqlExecer, buildingQueueItemID string) error {
 	const deleteBuildingQueueItemQuery = `
 DELETE FROM building_queue_view
 WHERE id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteBuildingQueueItemQuery,
 		buildingQueueItemID,
@@ -740,12 +1090,16 @@ WHERE id=$1
 }
 
 func (r *StickerioRepository) DeleteBuildingQueueItemsFromCity(ctx context.Context, cityID string) error {
+	return deleteBuildingQueueItemsFromCity(ctx, r.db, cityID)
+}
+
+func deleteBuildingQueueItemsFromCity(ctx context.Context, db sqlExecer, cityID string) error {
 	const deleteBuildingQueueItemQuery = `
 DELETE FROM building_queue_view
 WHERE city_id=$1
 `
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		deleteBuildingQueueItemQuery,
 		cityID,
@@ -756,3 +1110,855 @@ WHERE city_id=$1
 
 	return nil
 }
+
+func (r *StickerioRepository) ListResearchQueueItems(ctx context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbResearchQueueItem, error) {
+	filtersValues := []interface{}{cityID, playerID, lastID, pageSize}
+	const listResearchQueueItemsQuery = `
+SELECT
+id,
+city_id,
+player_id,
+queued_epoch,
+duration_s,
+research_name
+FROM research_queue_view
+WHERE city_id=$1 AND player_id=$2 AND id>$3
+ORDER BY id
+LIMIT $4
+`
+
+	rows, err := r.db.QueryContext(ctx, listResearchQueueItemsQuery, filtersValues...)
+	if err != nil {
+		return nil, fmt.Errorf("listResearchQueueItemsQuery failed: %w", err)
+	}
+
+	results := make([]*dbResearchQueueItem, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbResearchQueueItem{}
+		err := rows.Scan(
+			&result.id,
+			&result.cityID,
+			&result.playerID,
+			&result.queuedEpoch,
+			&result.durationSec,
+			&result.researchName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *StickerioRepository) UpsertResearchQueueItem(ctx context.Context, m *dbResearchQueueItem) error {
+	return upsertResearchQueueItem(ctx, r.db, m)
+}
+
+func upsertResearchQueueItem(ctx context.Context, db sqlExecer, m *dbResearchQueueItem) error {
+	const upsertResearchQueueItemQuery = `
+INSERT INTO research_queue_view(
+id,
+city_id,
+player_id,
+queued_epoch,
+duration_s,
+research_name)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT(id) DO UPDATE SET
+city_id=excluded.city_id,
+player_id=excluded.player_id,
+queued_epoch=excluded.queued_epoch,
+duration_s=excluded.duration_s,
+research_name=excluded.research_name
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		upsertResearchQueueItemQuery,
+		m.id,
+		m.cityID,
+		m.playerID,
+		m.queuedEpoch,
+		m.durationSec,
+		m.researchName,
+	)
+	if err != nil {
+		return fmt.Errorf("upsertResearchQueueItemQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) DeleteResearchQueueItem(ctx context.Context, researchQueueItemID string) error {
+	return deleteResearchQueueItem(ctx, r.db, researchQueueItemID)
+}
+
+func deleteResearchQueueItem(ctx context.Context, db sqlExecer, researchQueueItemID string) error {
+	const deleteResearchQueueItemQuery = `
+DELETE FROM research_queue_view
+WHERE id=$1
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		deleteResearchQueueItemQuery,
+		researchQueueItemID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleteResearchQueueItemQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) DeleteResearchQueueItemsFromCity(ctx context.Context, cityID string) error {
+	return deleteResearchQueueItemsFromCity(ctx, r.db, cityID)
+}
+
+func deleteResearchQueueItemsFromCity(ctx context.Context, db sqlExecer, cityID string) error {
+	const deleteResearchQueueItemsQuery = `
+DELETE FROM research_queue_view
+WHERE city_id=$1
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		deleteResearchQueueItemsQuery,
+		cityID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleteResearchQueueItemsQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+// A player without any row yet has simply not researched anything.
+func (r *StickerioRepository) GetPlayerResearch(ctx context.Context, playerID string) (string, error) {
+	const getPlayerResearchQuery = `
+SELECT
+COALESCE(research, '')
+FROM players
+WHERE id=$1
+`
+
+	var research string
+	row := r.db.QueryRowContext(ctx, getPlayerResearchQuery, playerID)
+	err := row.Scan(&research)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("getPlayerResearchQuery scan: %w", err)
+	}
+
+	return research, nil
+}
+
+func (r *StickerioRepository) GetAlliance(ctx context.Context, id string) (*dbAlliance, error) {
+	const getAllianceQuery = `
+SELECT
+id,
+alliance_name,
+leader_id,
+invites
+FROM alliances_view
+WHERE id=$1
+`
+
+	row := r.db.QueryRowContext(ctx, getAllianceQuery, id)
+	result := &dbAlliance{}
+	err := row.Scan(
+		&result.id,
+		&result.name,
+		&result.leaderID,
+		&result.invites,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getAllianceQuery scan: %w", err)
+	}
+
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	const listAllianceMembersQuery = `
+SELECT
+id
+FROM players
+WHERE alliance_id=$1
+ORDER BY id
+`
+
+	rows, err := r.db.QueryContext(ctx, listAllianceMembersQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("listAllianceMembersQuery failed: %w", err)
+	}
+
+	result.members = make([]tPlayerID, 0)
+	for rows.Next() {
+		var member tPlayerID
+		err := rows.Scan(&member)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		result.members = append(result.members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StickerioRepository) ListAllianceInfo(ctx context.Context, lastID string, pageSize int) ([]*dbAlliance, error) {
+	const listAllianceInfoQuery = `
+SELECT
+id,
+alliance_name,
+leader_id
+FROM alliances_view
+WHERE id>$1
+ORDER BY id
+LIMIT $2
+`
+
+	rows, err := r.db.QueryContext(ctx, listAllianceInfoQuery, lastID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("listAllianceInfoQuery failed: %w", err)
+	}
+
+	results := make([]*dbAlliance, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbAlliance{}
+		err := rows.Scan(
+			&result.id,
+			&result.name,
+			&result.leaderID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *StickerioRepository) UpsertAlliance(ctx context.Context, a *dbAlliance) error {
+	return upsertAlliance(ctx, r.db, a)
+}
+
+func upsertAlliance(ctx context.Context, db sqlExecer, a *dbAlliance) error {
+	const upsertAllianceQuery = `
+INSERT INTO alliances_view(
+id,
+alliance_name,
+leader_id,
+invites)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT(id) DO UPDATE SET
+alliance_name=excluded.alliance_name,
+leader_id=excluded.leader_id,
+invites=excluded.invites
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		upsertAllianceQuery,
+		a.id,
+		a.name,
+		a.leaderID,
+		a.invites,
+	)
+	if err != nil {
+		return fmt.Errorf("upsertAllianceQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) DeleteAlliance(ctx context.Context, allianceID string) error {
+	return deleteAlliance(ctx, r.db, allianceID)
+}
+
+func deleteAlliance(ctx context.Context, db sqlExecer, allianceID string) error {
+	const deleteAllianceQuery = `
+DELETE FROM alliances_view
+WHERE id=$1
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		deleteAllianceQuery,
+		allianceID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleteAllianceQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) GetTradeOffer(ctx context.Context, id string) (*dbTradeOffer, error) {
+	const getTradeOfferQuery = `
+SELECT
+id,
+player_id,
+city_id,
+offered,
+requested,
+created_epoch
+FROM trade_offers_view
+WHERE id=$1
+`
+
+	row := r.db.QueryRowContext(ctx, getTradeOfferQuery, id)
+	result := &dbTradeOffer{}
+	err := row.Scan(
+		&result.id,
+		&result.playerID,
+		&result.cityID,
+		jsonColumn{&result.offered},
+		jsonColumn{&result.requested},
+		&result.createdEpoch,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getTradeOfferQuery scan: %w", err)
+	}
+
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StickerioRepository) ListTradeOffers(ctx context.Context, lastID string, pageSize int) ([]*dbTradeOffer, error) {
+	const listTradeOffersQuery = `
+SELECT
+id,
+player_id,
+city_id,
+offered,
+requested,
+created_epoch
+FROM trade_offers_view
+WHERE id>$1
+ORDER BY id
+LIMIT $2
+`
+
+	rows, err := r.db.QueryContext(ctx, listTradeOffersQuery, lastID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("listTradeOffersQuery failed: %w", err)
+	}
+
+	results := make([]*dbTradeOffer, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbTradeOffer{}
+		err := rows.Scan(
+			&result.id,
+			&result.playerID,
+			&result.cityID,
+			jsonColumn{&result.offered},
+			jsonColumn{&result.requested},
+			&result.createdEpoch,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func upsertTradeOffer(ctx context.Context, db sqlExecer, o *dbTradeOffer) error {
+	const upsertTradeOfferQuery = `
+INSERT INTO trade_offers_view(
+id,
+player_id,
+city_id,
+offered,
+requested,
+created_epoch)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT(id) DO UPDATE SET
+player_id=excluded.player_id,
+city_id=excluded.city_id,
+offered=excluded.offered,
+requested=excluded.requested,
+created_epoch=excluded.created_epoch
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		upsertTradeOfferQuery,
+		o.id,
+		o.playerID,
+		o.cityID,
+		o.offered,
+		o.requested,
+		o.createdEpoch,
+	)
+	if err != nil {
+		return fmt.Errorf("upsertTradeOfferQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func deleteTradeOffer(ctx context.Context, db sqlExecer, tradeOfferID string) error {
+	const deleteTradeOfferQuery = `
+DELETE FROM trade_offers_view
+WHERE id=$1
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		deleteTradeOfferQuery,
+		tradeOfferID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleteTradeOfferQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) GetPlayer(ctx context.Context, id string) (*dbPlayer, error) {
+	const getPlayerQuery = `
+SELECT
+id,
+COALESCE(alliance_id, ''),
+COALESCE(score, 0),
+COALESCE(attack_score, 0),
+COALESCE(defence_score, 0),
+COALESCE(research, '')
+FROM players
+WHERE id=$1
+`
+
+	row := r.db.QueryRowContext(ctx, getPlayerQuery, id)
+	result := &dbPlayer{}
+	err := row.Scan(
+		&result.id,
+		&result.allianceID,
+		&result.score,
+		&result.attackPoints,
+		&result.defencePoints,
+		&result.research,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getPlayerQuery scan: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StickerioRepository) UpsertPlayer(ctx context.Context, p *dbPlayer) error {
+	return upsertPlayer(ctx, r.db, p)
+}
+
+func upsertPlayer(ctx context.Context, db sqlExecer, p *dbPlayer) error {
+	const upsertPlayerQuery = `
+INSERT INTO players(
+id,
+alliance_id,
+score,
+attack_score,
+defence_score,
+research)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT(id) DO UPDATE SET
+alliance_id=excluded.alliance_id,
+score=excluded.score,
+attack_score=excluded.attack_score,
+defence_score=excluded.defence_score,
+research=excluded.research
+`
+
+	_, err := db.ExecContext(
+		ctx,
+		upsertPlayerQuery,
+		p.id,
+		p.allianceID,
+		p.score,
+		p.attackPoints,
+		p.defencePoints,
+		p.research,
+	)
+	if err != nil {
+		return fmt.Errorf("upsertPlayerQuery failed: %w", err)
+	}
+
+	return nil
+}
+
+// Rankings are paginated by the ID of the last entry of the previous page,
+// since the position of that entry is what the next page starts after.
+func (r *StickerioRepository) ListLeaderboard(ctx context.Context, kind tLeaderboardKind, lastID string, pageSize int) ([]*dbLeaderboardEntry, error) {
+	var scoresQuery string
+	switch kind {
+	case overallLeaderboard:
+		scoresQuery = "SELECT id, COALESCE(score, 0) AS score FROM players"
+	case attackLeaderboard:
+		scoresQuery = "SELECT id, COALESCE(attack_score, 0) AS score FROM players"
+	case defenceLeaderboard:
+		scoresQuery = "SELECT id, COALESCE(defence_score, 0) AS score FROM players"
+	case allianceLeaderboard:
+		scoresQuery = "SELECT a.id AS id, COALESCE(SUM(p.score), 0) AS score FROM alliances_view a LEFT JOIN players p ON p.alliance_id=a.id GROUP BY a.id"
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %s", kind)
+	}
+
+	listLeaderboardQuery := fmt.Sprintf(`
+WITH ranked AS (
+	SELECT
+	id,
+	score,
+	ROW_NUMBER() OVER (ORDER BY score DESC, id) AS position
+	FROM (%s) scores
+)
+SELECT
+id,
+position,
+score
+FROM ranked
+WHERE position>COALESCE((SELECT position FROM ranked WHERE id=$1), 0)
+ORDER BY position
+LIMIT $2
+`, scoresQuery)
+
+	rows, err := r.db.QueryContext(ctx, listLeaderboardQuery, lastID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("listLeaderboardQuery failed: %w", err)
+	}
+
+	results := make([]*dbLeaderboardEntry, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbLeaderboardEntry{}
+		err := rows.Scan(
+			&result.id,
+			&result.rank,
+			&result.score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *StickerioRepository) InsertMail(ctx context.Context, m *dbMail, recipients []tPlayerID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = insertMail(ctx, tx, m, recipients)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertMail delivers a mail unless a mail with the same ID exists, in which
+// case nobody gets it.
+func insertMail(ctx context.Context, db sqlExecer, m *dbMail, recipients []tPlayerID) error {
+	const insertMailQuery = `
+INSERT INTO mail_messages(
+id,
+sender_id,
+recipient_id,
+alliance_id,
+subject,
+content,
+sent_epoch,
+sender_deleted)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 0)
+ON CONFLICT(id) DO NOTHING
+`
+	const insertInboxQuery = `
+INSERT INTO mail_inbox(
+mail_id,
+player_id,
+read,
+deleted)
+VALUES ($1, $2, 0, 0)
+ON CONFLICT(mail_id, player_id) DO NOTHING
+`
+
+	res, err := db.ExecContext(
+		ctx,
+		insertMailQuery,
+		m.id,
+		m.senderID,
+		m.recipientID,
+		m.allianceID,
+		m.subject,
+		m.content,
+		m.sentEpoch,
+	)
+	if err != nil {
+		return fmt.Errorf("insertMailQuery failed: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insertMailQuery failed: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("insertMailQuery: mail %s %w", m.id, errAlreadyExists)
+	}
+	for _, recipient := range recipients {
+		_, err = db.ExecContext(ctx, insertInboxQuery, m.id, recipient)
+		if err != nil {
+			return fmt.Errorf("insertInboxQuery failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *StickerioRepository) GetMail(ctx context.Context, id, playerID string) (*dbMail, error) {
+	const getMailQuery = `
+SELECT
+m.id,
+m.sender_id,
+m.recipient_id,
+m.alliance_id,
+m.subject,
+m.content,
+m.sent_epoch,
+COALESCE(i.read, 1)
+FROM mail_messages m
+LEFT JOIN mail_inbox i ON i.mail_id=m.id AND i.player_id=$2 AND i.deleted=0
+WHERE m.id=$1 AND (i.player_id IS NOT NULL OR (m.sender_id=$2 AND m.sender_deleted=0))
+`
+
+	row := r.db.QueryRowContext(ctx, getMailQuery, id, playerID)
+	result := &dbMail{}
+	err := row.Scan(
+		&result.id,
+		&result.senderID,
+		&result.recipientID,
+		&result.allianceID,
+		&result.subject,
+		&result.content,
+		&result.sentEpoch,
+		&result.read,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getMailQuery scan: %w", err)
+	}
+
+	if err := row.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StickerioRepository) ListInbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error) {
+	const listInboxQuery = `
+SELECT
+m.id,
+m.sender_id,
+m.recipient_id,
+m.alliance_id,
+m.subject,
+m.content,
+m.sent_epoch,
+i.read
+FROM mail_inbox i
+JOIN mail_messages m ON m.id=i.mail_id
+WHERE i.player_id=$1 AND i.deleted=0 AND i.mail_id>$2
+ORDER BY i.mail_id
+LIMIT $3
+`
+
+	return r.listMail(ctx, listInboxQuery, playerID, lastID, pageSize)
+}
+
+func (r *StickerioRepository) ListOutbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error) {
+	const listOutboxQuery = `
+SELECT
+id,
+sender_id,
+recipient_id,
+alliance_id,
+subject,
+content,
+sent_epoch,
+1
+FROM mail_messages
+WHERE sender_id=$1 AND sender_deleted=0 AND id>$2
+ORDER BY id
+LIMIT $3
+`
+
+	return r.listMail(ctx, listOutboxQuery, playerID, lastID, pageSize)
+}
+
+func (r *StickerioRepository) listMail(ctx context.Context, query, playerID, lastID string, pageSize int) ([]*dbMail, error) {
+	rows, err := r.db.QueryContext(ctx, query, playerID, lastID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("listMailQuery failed: %w", err)
+	}
+
+	results := make([]*dbMail, 0, pageSize)
+
+	for rows.Next() {
+		result := &dbMail{}
+		err := rows.Scan(
+			&result.id,
+			&result.senderID,
+			&result.recipientID,
+			&result.allianceID,
+			&result.subject,
+			&result.content,
+			&result.sentEpoch,
+			&result.read,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *StickerioRepository) MarkMailRead(ctx context.Context, id, playerID string) error {
+	const markMailReadQuery = `
+UPDATE mail_inbox
+SET read=1
+WHERE mail_id=$1 AND player_id=$2 AND deleted=0
+`
+
+	res, err := r.db.ExecContext(ctx, markMailReadQuery, id, playerID)
+	if err != nil {
+		return fmt.Errorf("markMailReadQuery failed: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("markMailReadQuery failed: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("markMailReadQuery: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// NOTE: mail is only soft deleted so that system generated mail is not
+// inserted again when the events are re-synced.
+func (r *StickerioRepository) DeleteMail(ctx context.Context, id, playerID string) error {
+	const deleteInboxMailQuery = `
+UPDATE mail_inbox
+SET deleted=1
+WHERE mail_id=$1 AND player_id=$2
+`
+	const deleteOutboxMailQuery = `
+UPDATE mail_messages
+SET sender_deleted=1
+WHERE id=$1 AND sender_id=$2
+`
+
+	var affected int64
+	for _, query := range []string{deleteInboxMailQuery, deleteOutboxMailQuery} {
+		res, err := r.db.ExecContext(ctx, query, id, playerID)
+		if err != nil {
+			return fmt.Errorf("deleteMailQuery failed: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("deleteMailQuery failed: %w", err)
+		}
+		affected += n
+	}
+	if affected == 0 {
+		return fmt.Errorf("deleteMailQuery: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *StickerioRepository) ListConfigVersions(ctx context.Context) ([]*dbConfigVersion, error) {
+	const listConfigVersionsQuery = `
+SELECT
+version,
+effective_from,
+source_hash,
+config
+FROM config_versions
+ORDER BY version
+`
+	rows, err := r.db.QueryContext(ctx, listConfigVersionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("listConfigVersionsQuery failed: %w", err)
+	}
+
+	results := make([]*dbConfigVersion, 0)
+
+	for rows.Next() {
+		result := &dbConfigVersion{}
+		err := rows.Scan(
+			&result.version,
+			&result.effectiveFrom,
+			&result.sourceHash,
+			&result.config,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *StickerioRepository) InsertConfigVersion(ctx context.Context, v *dbConfigVersion) error {
+	const insertConfigVersionQuery = `
+INSERT INTO config_versions(version, effective_from, source_hash, config) VALUES ($1, $2, $3, $4)
+`
+	_, err := r.db.ExecContext(ctx, insertConfigVersionQuery, v.version, v.effectiveFrom, v.sourceHash, v.config)
+	if err != nil {
+		return fmt.Errorf("insertConfigVersionQuery failed: %w", err)
+	}
+	return nil
+}
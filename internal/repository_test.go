@@ -1 +1,691 @@
 package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+)
+
+// postgresUnavailable is why there is no postgres to run the conformance
+// tests against.
+var postgresUnavailable string
+
+// TestMain starts a throwaway postgres for the conformance tests unless
+// STICKERIO_TEST_POSTGRES_DSN already points to one. The binaries are fetched
+// and cached on the first run, a machine that cannot get them skips the
+// postgres tests, or fails them when STICKERIO_TEST_POSTGRES_REQUIRED is set.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(runWithPostgres(m))
+}
+
+func runWithPostgres(m *testing.M) int {
+	if os.Getenv("STICKERIO_TEST_POSTGRES_DSN") != "" {
+		return m.Run()
+	}
+	if testing.Short() {
+		postgresUnavailable = "no postgres is started in short mode"
+		return m.Run()
+	}
+
+	dataSourceName, stop, err := startEmbeddedPostgres()
+	if err != nil {
+		postgresUnavailable = fmt.Sprintf("embedded postgres did not start: %v", err)
+		if os.Getenv("STICKERIO_TEST_POSTGRES_REQUIRED") != "" {
+			fmt.Fprintln(os.Stderr, postgresUnavailable)
+			return 1
+		}
+		return m.Run()
+	}
+	defer stop()
+	os.Setenv("STICKERIO_TEST_POSTGRES_DSN", dataSourceName)
+	return m.Run()
+}
+
+// startEmbeddedPostgres returns the data source name of the database and how
+// to stop it.
+func startEmbeddedPostgres() (string, func(), error) {
+	// the port the OS hands out is free for the database to take
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return "", nil, err
+	}
+	port := uint32(listener.Addr().(*net.TCPAddr).Port)
+	listener.Close()
+
+	runtimePath, err := os.MkdirTemp("", "stickerio-postgres-")
+	if err != nil {
+		return "", nil, err
+	}
+	config := embeddedpostgres.DefaultConfig().
+		Port(port).
+		RuntimePath(runtimePath).
+		Logger(io.Discard)
+	database := embeddedpostgres.NewDatabase(config)
+	err = database.Start()
+	if err != nil {
+		os.RemoveAll(runtimePath)
+		return "", nil, err
+	}
+	stop := func() {
+		database.Stop()
+		os.RemoveAll(runtimePath)
+	}
+	return config.GetConnectionURL() + "?sslmode=disable", stop, nil
+}
+
+// conformanceRepository is every storage interface a repository implements.
+type conformanceRepository interface {
+	eventsRepository
+	viewerRepository
+	eventInserter
+	mailRepository
+	configRepository
+}
+
+func newTestSQLiteRepository(t *testing.T) *StickerioRepository {
+	t.Helper()
+	r := NewStickerioRepository("file:" + t.TempDir() + "/stickerio.db")
+	t.Cleanup(func() { r.db.Close() })
+	_, err := r.MigrateUp(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// newTestPostgresRepository migrates a schema of its own in the database of
+// STICKERIO_TEST_POSTGRES_DSN, e.g. the one TestMain starts, and skips when
+// there is none.
+func newTestPostgresRepository(t *testing.T) *StickerioRepository {
+	t.Helper()
+	dataSourceName := os.Getenv("STICKERIO_TEST_POSTGRES_DSN")
+	if dataSourceName == "" {
+		t.Skip(postgresUnavailable)
+	}
+
+	admin := NewStickerioRepository(dataSourceName)
+	t.Cleanup(func() { admin.db.Close() })
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	_, err := admin.db.Exec("CREATE SCHEMA " + schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.db.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	// unknown parameters are sent to the server as run-time settings
+	dsn, err := url.Parse(dataSourceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := dsn.Query()
+	query.Set("search_path", schema)
+	dsn.RawQuery = query.Encode()
+
+	r := NewStickerioRepository(dsn.String())
+	t.Cleanup(func() { r.db.Close() })
+	_, err = r.MigrateUp(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// runConformance runs the same test against every repository implementation,
+// so that the in-memory one can be trusted in place of the SQL one.
+func runConformance(t *testing.T, test func(t *testing.T, r conformanceRepository)) {
+	implementations := map[string]func(t *testing.T) conformanceRepository{
+		"inmemory": func(t *testing.T) conformanceRepository { return newInMemoryRepository() },
+		"sqlite":   func(t *testing.T) conformanceRepository { return newTestSQLiteRepository(t) },
+		// NOTE: skipped, saying why, where no postgres can be started, e.g., offline
+		"postgres": func(t *testing.T) conformanceRepository { return newTestPostgresRepository(t) },
+	}
+	for name, newRepository := range implementations {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func mustNoRows(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected no rows, got: %v", err)
+	}
+}
+
+func mustEqual(t *testing.T, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRepositoryEvents(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		events := []*event{
+			{id: "b", name: createCityEventName, epoch: 10, payload: "{}"},
+			{id: "a", name: createCityEventName, epoch: 10, payload: "{}"},
+			{id: "c", name: deleteCityEventName, epoch: 5, payload: "{}"},
+			{id: "d", name: deleteCityEventName, epoch: 20, payload: "{}"},
+		}
+		for _, e := range events {
+			mustNoErr(t, r.InsertEvent(ctx, e))
+		}
+		// inserting the same event again is a no-op
+		mustNoErr(t, r.InsertEvent(ctx, &event{id: "a", name: deleteCityEventName, epoch: 1, payload: "{}"}))
+
+		got, err := r.ListEvents(ctx, 10)
+		mustNoErr(t, err)
+		mustEqual(t, got, []*event{events[2], events[1], events[0]})
+	})
+}
+
+func TestRepositoryCities(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		cities := []*dbCity{
+			{id: "c1", name: "one", playerID: "p1", locationX: 0, locationY: 0, buildingsLevel: `{"mines":1}`, resourceBase: `{"wood":1}`, resourceEpoch: 3, unitCount: `{"stickmen":2}`, protectedUntil: 7, reinforcements: `{"p2":{"stickmen":1}}`},
+			{id: "c2", name: "two", playerID: "p2", locationX: 5, locationY: 5, buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"},
+			{id: "c3", name: "three", playerID: "p1", locationX: 10, locationY: -5, buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"},
+		}
+		for _, c := range cities {
+			mustNoErr(t, r.UpsertCity(ctx, c))
+		}
+		renamed := *cities[0]
+		renamed.name = "uno"
+		mustNoErr(t, r.UpsertCity(ctx, &renamed))
+
+		got, err := r.GetCity(ctx, "c1", "p1")
+		mustNoErr(t, err)
+		mustEqual(t, got, &renamed)
+		_, err = r.GetCity(ctx, "c1", "p2")
+		mustNoRows(t, err)
+		_, err = r.GetCity(ctx, "c4", "p1")
+		mustNoRows(t, err)
+
+		// alliance members see each other's cities
+		mustNoErr(t, r.UpsertPlayer(ctx, &dbPlayer{id: "p1", allianceID: "a1"}))
+		mustNoErr(t, r.UpsertPlayer(ctx, &dbPlayer{id: "p2", allianceID: "a1"}))
+		mustNoErr(t, r.UpsertPlayer(ctx, &dbPlayer{id: "p3"}))
+		_, err = r.GetCity(ctx, "c1", "p2")
+		mustNoErr(t, err)
+		_, err = r.GetCity(ctx, "c1", "p3")
+		mustNoRows(t, err)
+
+		info, err := r.GetCityInfo(ctx, "c1")
+		mustNoErr(t, err)
+		mustEqual(t, info, &dbCity{id: "c1", name: "uno", playerID: "p1", protectedUntil: 7})
+
+		page, err := r.ListCityInfo(ctx, "", 2)
+		mustNoErr(t, err)
+		mustEqual(t, len(page), 2)
+		mustEqual(t, page[1], &dbCity{id: "c2", name: "two", playerID: "p2", locationX: 5, locationY: 5})
+		page, err = r.ListCityInfo(ctx, "c2", 2)
+		mustNoErr(t, err)
+		mustEqual(t, len(page), 1)
+		mustEqual(t, page[0].id, tCityID("c3"))
+
+		page, err = r.ListCityInfo(ctx, "", 10, withPlayerID("p1"))
+		mustNoErr(t, err)
+		mustEqual(t, []tCityID{page[0].id, page[1].id}, []tCityID{"c1", "c3"})
+		page, err = r.ListCityInfo(ctx, "", 10, withinLocation(0, -5, 5, 5)...)
+		mustNoErr(t, err)
+		mustEqual(t, []tCityID{page[0].id, page[1].id}, []tCityID{"c1", "c2"})
+
+		mustNoErr(t, r.DeleteCity(ctx, "c1"))
+		_, err = r.GetCityInfo(ctx, "c1")
+		mustNoRows(t, err)
+	})
+}
+
+func TestRepositoryCitiesNear(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		cities := []*dbCity{
+			{id: "c1", playerID: "p1", locationX: 0, locationY: 0},
+			{id: "c2", playerID: "p1", locationX: 3, locationY: 4},
+			{id: "c3", playerID: "p1", locationX: -3, locationY: -4},
+			{id: "c4", playerID: "p1", locationX: 6, locationY: 8},
+			{id: "c5", playerID: "p2", locationX: 1, locationY: 0},
+		}
+		for _, c := range cities {
+			c.buildingsLevel, c.resourceBase, c.unitCount, c.reinforcements = "{}", "{}", "{}", "{}"
+			mustNoErr(t, r.UpsertCity(ctx, c))
+		}
+		ids := func(page []*dbCity) []tCityID {
+			result := make([]tCityID, len(page))
+			for i, c := range page {
+				result[i] = c.id
+			}
+			return result
+		}
+
+		// ties in distance are ordered by ID, also across pages
+		page, err := r.ListCityInfoNear(ctx, 0, 0, -1, "", 2)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c1", "c5"})
+		page, err = r.ListCityInfoNear(ctx, 0, 0, -1, "c5", 2)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c2", "c3"})
+		page, err = r.ListCityInfoNear(ctx, 0, 0, -1, "c3", 2)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c4"})
+		// the last city of a page is where the next page goes on from, even when filtered out by now
+		page, err = r.ListCityInfoNear(ctx, 0, 0, -1, "c5", 2, withPlayerID("p1"))
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c2", "c3"})
+
+		page, err = r.ListCityInfoNear(ctx, 0, 0, 5, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c1", "c5", "c2", "c3"})
+		page, err = r.ListCityInfoNear(ctx, 0, 0, 0, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c1"})
+		page, err = r.ListCityInfoNear(ctx, 6, 8, 5, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c4", "c2"})
+		page, err = r.ListCityInfoNear(ctx, 0, 0, 5, "", 10, withPlayerID("p2"))
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c5"})
+		page, err = r.ListCityInfoNear(ctx, 0, 0, -1, "", 10, withinLocation(-5, -5, 0, 0)...)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c1", "c3"})
+
+		// a page cannot go on from a city that is gone, it would start over
+		mustNoErr(t, r.DeleteCity(ctx, "c5"))
+		_, err = r.ListCityInfoNear(ctx, 0, 0, -1, "c5", 2)
+		if !errors.Is(err, errInvalidRequest) {
+			t.Fatalf("got %v, want %v", err, errInvalidRequest)
+		}
+
+		// far away cities are further than the integer columns can square
+		far := &dbCity{id: "c6", playerID: "p1", locationX: 1 << 29, locationY: -(1 << 29), buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"}
+		mustNoErr(t, r.UpsertCity(ctx, far))
+		page, err = r.ListCityInfoNear(ctx, -(1 << 29), 1<<29, -1, "c2", 10)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c1", "c3", "c6"})
+		page, err = r.ListCityInfoNear(ctx, 1<<29, -(1 << 29), math.MaxInt32, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, ids(page), []tCityID{"c6", "c3", "c1", "c2", "c4"})
+	})
+}
+
+func TestRepositoryMovements(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		movements := []*dbMovement{
+			{id: "m1", playerID: "p1", originID: "c1", destinationID: "c2", destinationX: 1, destinationY: 2, departureEpoch: 3, speed: 1.5, resourceCount: `{"sticks":5}`, unitCount: "{}", movementType: transportMovement},
+			{id: "m2", playerID: "p1", originID: "c2", destinationID: "c1", resourceCount: "{}", unitCount: "{}"},
+			{id: "m3", playerID: "p1", originID: "c1", destinationID: "c3", resourceCount: "{}", unitCount: "{}"},
+			{id: "m4", playerID: "p2", originID: "c1", destinationID: "c2", resourceCount: "{}", unitCount: "{}"},
+		}
+		for _, m := range movements {
+			mustNoErr(t, r.UpsertMovement(ctx, m))
+		}
+
+		got, err := r.GetMovement(ctx, "m1", "p1")
+		mustNoErr(t, err)
+		mustEqual(t, got, movements[0])
+		_, err = r.GetMovement(ctx, "m1", "p2")
+		mustNoRows(t, err)
+
+		page, err := r.ListMovements(ctx, "p1", "m1", 10)
+		mustNoErr(t, err)
+		mustEqual(t, page, []*dbMovement{movements[1], movements[2]})
+		page, err = r.ListMovements(ctx, "p1", "", 10, withOriginCityID("c1"))
+		mustNoErr(t, err)
+		mustEqual(t, page, []*dbMovement{movements[0], movements[2]})
+		page, err = r.ListMovements(ctx, "p1", "", 1, withOriginCityID("c1"), withDestinationCityID("c3"))
+		mustNoErr(t, err)
+		mustEqual(t, page, []*dbMovement{movements[2]})
+
+		mustNoErr(t, r.DeleteMovement(ctx, "m1"))
+		_, err = r.GetMovement(ctx, "m1", "p1")
+		mustNoRows(t, err)
+	})
+}
+
+func TestRepositoryQueues(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		unitItems := []*dbUnitQueueItem{
+			{id: "u1", cityID: "c1", playerID: "p1", queuedEpoch: 1, durationSec: 2, unitCount: 3, unitType: "stickmen"},
+			{id: "u2", cityID: "c1", playerID: "p1", unitType: "stickmen"},
+			{id: "u3", cityID: "c2", playerID: "p1", unitType: "stickmen"},
+		}
+		for _, item := range unitItems {
+			mustNoErr(t, r.UpsertUnitQueueItem(ctx, item))
+		}
+		buildingItems := []*dbBuildingQueueItem{
+			{id: "b1", cityID: "c1", playerID: "p1", queuedEpoch: 1, durationSec: 2, targetLevel: 3, targetBuilding: "mines"},
+			{id: "b2", cityID: "c2", playerID: "p1", targetBuilding: "mines"},
+		}
+		for _, item := range buildingItems {
+			mustNoErr(t, r.UpsertBuildingQueueItem(ctx, item))
+		}
+		researchItems := []*dbResearchQueueItem{
+			{id: "r1", cityID: "c1", playerID: "p1", queuedEpoch: 1, durationSec: 2, researchName: "masonry"},
+			{id: "r2", cityID: "c1", playerID: "p1", researchName: "swordsmanship"},
+		}
+		for _, item := range researchItems {
+			mustNoErr(t, r.UpsertResearchQueueItem(ctx, item))
+		}
+
+		unitItem, err := r.GetUnitQueueItem(ctx, "u1", "c1", "p1")
+		mustNoErr(t, err)
+		mustEqual(t, unitItem, unitItems[0])
+		_, err = r.GetUnitQueueItem(ctx, "u1", "c2", "p1")
+		mustNoRows(t, err)
+		unitPage, err := r.ListUnitQueueItems(ctx, "c1", "p1", "", 1)
+		mustNoErr(t, err)
+		mustEqual(t, unitPage, []*dbUnitQueueItem{unitItems[0]})
+		unitPage, err = r.ListUnitQueueItems(ctx, "c1", "p2", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(unitPage), 0)
+
+		buildingItem, err := r.GetBuildingQueueItem(ctx, "b1", "c1", "p1")
+		mustNoErr(t, err)
+		mustEqual(t, buildingItem, buildingItems[0])
+		buildingPage, err := r.ListBuildingQueueItems(ctx, "c2", "p1", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, buildingPage, []*dbBuildingQueueItem{buildingItems[1]})
+
+		researchPage, err := r.ListResearchQueueItems(ctx, "c1", "p1", "r1", 10)
+		mustNoErr(t, err)
+		mustEqual(t, researchPage, []*dbResearchQueueItem{researchItems[1]})
+
+		mustNoErr(t, r.DeleteUnitQueueItem(ctx, "u3"))
+		mustNoErr(t, r.DeleteUnitQueueItemsFromCity(ctx, "c1"))
+		mustNoErr(t, r.DeleteBuildingQueueItem(ctx, "b2"))
+		mustNoErr(t, r.DeleteBuildingQueueItemsFromCity(ctx, "c1"))
+		mustNoErr(t, r.DeleteResearchQueueItem(ctx, "r2"))
+		mustNoErr(t, r.DeleteResearchQueueItemsFromCity(ctx, "c1"))
+		for _, cityID := range []string{"c1", "c2"} {
+			unitPage, err = r.ListUnitQueueItems(ctx, cityID, "p1", "", 10)
+			mustNoErr(t, err)
+			buildingPage, err = r.ListBuildingQueueItems(ctx, cityID, "p1", "", 10)
+			mustNoErr(t, err)
+			researchPage, err = r.ListResearchQueueItems(ctx, cityID, "p1", "", 10)
+			mustNoErr(t, err)
+			mustEqual(t, len(unitPage)+len(buildingPage)+len(researchPage), 0)
+		}
+	})
+}
+
+func TestRepositoryAlliancesAndPlayers(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		mustNoErr(t, r.UpsertAlliance(ctx, &dbAlliance{id: "a1", name: "first", leaderID: "p2", invites: `["p4"]`}))
+		mustNoErr(t, r.UpsertAlliance(ctx, &dbAlliance{id: "a2", name: "second", leaderID: "p3", invites: "[]"}))
+		mustNoErr(t, r.UpsertAlliance(ctx, &dbAlliance{id: "a3", name: "third", leaderID: "p5", invites: "[]"}))
+		players := []*dbPlayer{
+			{id: "p1", allianceID: "a1", score: 10, attackPoints: 1, defencePoints: 7, research: `["masonry"]`},
+			{id: "p2", allianceID: "a1", score: 30, attackPoints: 5, defencePoints: 7},
+			{id: "p3", allianceID: "a2", score: 50, attackPoints: 3},
+			{id: "p4", score: 20},
+		}
+		for _, p := range players {
+			mustNoErr(t, r.UpsertPlayer(ctx, p))
+		}
+
+		a, err := r.GetAlliance(ctx, "a1")
+		mustNoErr(t, err)
+		mustEqual(t, a, &dbAlliance{id: "a1", name: "first", leaderID: "p2", invites: `["p4"]`, members: []tPlayerID{"p1", "p2"}})
+		a, err = r.GetAlliance(ctx, "a3")
+		mustNoErr(t, err)
+		mustEqual(t, a.members, []tPlayerID{})
+		_, err = r.GetAlliance(ctx, "a4")
+		mustNoRows(t, err)
+
+		alliances, err := r.ListAllianceInfo(ctx, "a1", 1)
+		mustNoErr(t, err)
+		mustEqual(t, alliances, []*dbAlliance{{id: "a2", name: "second", leaderID: "p3"}})
+
+		p, err := r.GetPlayer(ctx, "p2")
+		mustNoErr(t, err)
+		mustEqual(t, p, players[1])
+		_, err = r.GetPlayer(ctx, "p9")
+		mustNoRows(t, err)
+
+		research, err := r.GetPlayerResearch(ctx, "p1")
+		mustNoErr(t, err)
+		mustEqual(t, research, `["masonry"]`)
+		research, err = r.GetPlayerResearch(ctx, "p9")
+		mustNoErr(t, err)
+		mustEqual(t, research, "")
+
+		leaderboard, err := r.ListLeaderboard(ctx, overallLeaderboard, "", 2)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "p3", rank: 1, score: 50}, {id: "p2", rank: 2, score: 30}})
+		leaderboard, err = r.ListLeaderboard(ctx, overallLeaderboard, "p2", 2)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "p4", rank: 3, score: 20}, {id: "p1", rank: 4, score: 10}})
+		// ties are ranked by id
+		leaderboard, err = r.ListLeaderboard(ctx, defenceLeaderboard, "", 2)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "p1", rank: 1, score: 7}, {id: "p2", rank: 2, score: 7}})
+		leaderboard, err = r.ListLeaderboard(ctx, attackLeaderboard, "p3", 1)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "p1", rank: 3, score: 1}})
+		leaderboard, err = r.ListLeaderboard(ctx, allianceLeaderboard, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "a2", rank: 1, score: 50}, {id: "a1", rank: 2, score: 40}, {id: "a3", rank: 3, score: 0}})
+		_, err = r.ListLeaderboard(ctx, "unknown", "", 10)
+		if err == nil {
+			t.Fatal("expected an error on an unknown leaderboard")
+		}
+
+		mustNoErr(t, r.DeleteAlliance(ctx, "a1"))
+		_, err = r.GetAlliance(ctx, "a1")
+		mustNoRows(t, err)
+	})
+}
+
+func TestRepositoryTradeOffers(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		offers := []*dbTradeOffer{
+			{id: "o1", playerID: "p1", cityID: "c1", offered: `{"sticks":10}`, requested: `{"circles":5}`, createdEpoch: 3},
+			{id: "o2", playerID: "p2", cityID: "c2", offered: `{"circles":1}`, requested: `{"sticks":1}`},
+			{id: "o3", playerID: "p1", cityID: "c3", offered: `{"circles":1}`, requested: `{"sticks":2}`},
+		}
+		mustNoErr(t, r.UpsertViews(ctx, &dbViews{tradeOffers: offers}))
+
+		got, err := r.GetTradeOffer(ctx, "o1")
+		mustNoErr(t, err)
+		mustEqual(t, got, offers[0])
+		page, err := r.ListTradeOffers(ctx, "o1", 10)
+		mustNoErr(t, err)
+		mustEqual(t, page, []*dbTradeOffer{offers[1], offers[2]})
+		page, err = r.ListTradeOffers(ctx, "", 1)
+		mustNoErr(t, err)
+		mustEqual(t, page, []*dbTradeOffer{offers[0]})
+
+		mustNoErr(t, r.UpsertViews(ctx, &dbViews{deletedTradeOffers: []tTradeOfferID{"o1"}}))
+		_, err = r.GetTradeOffer(ctx, "o1")
+		mustNoRows(t, err)
+	})
+}
+
+func TestRepositoryViews(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		mustNoErr(t, r.UpsertViews(ctx, &dbViews{
+			cities: []*dbCity{
+				{id: "c1", name: "one", playerID: "p1", buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"},
+				{id: "c2", name: "two", playerID: "p1", buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"},
+			},
+			movements:          []*dbMovement{{id: "m1", playerID: "p1", originID: "c1", destinationID: "c2", resourceCount: "{}", unitCount: "{}"}},
+			unitQueueItems:     []*dbUnitQueueItem{{id: "u1", cityID: "c1", playerID: "p1", unitType: "stickmen"}},
+			buildingQueueItems: []*dbBuildingQueueItem{{id: "b1", cityID: "c1", playerID: "p1", targetBuilding: "mines"}},
+			researchQueueItems: []*dbResearchQueueItem{{id: "r1", cityID: "c2", playerID: "p1", researchName: "masonry"}},
+			alliances:          []*dbAlliance{{id: "a1", name: "first", leaderID: "p1", invites: "[]"}},
+			players:            []*dbPlayer{{id: "p1", allianceID: "a1", score: 10}},
+		}))
+		_, err := r.GetCity(ctx, "c2", "p1")
+		mustNoErr(t, err)
+		_, err = r.GetMovement(ctx, "m1", "p1")
+		mustNoErr(t, err)
+		_, err = r.GetAlliance(ctx, "a1")
+		mustNoErr(t, err)
+		leaderboard, err := r.ListLeaderboard(ctx, overallLeaderboard, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, leaderboard, []*dbLeaderboardEntry{{id: "p1", rank: 1, score: 10}})
+
+		// deleting a city deletes its queues as well
+		mustNoErr(t, r.UpsertViews(ctx, &dbViews{
+			deletedCities:             []tCityID{"c1"},
+			deletedMovements:          []tMovementID{"m1"},
+			deletedResearchQueueItems: []tResearchQueueItemID{"r1"},
+			deletedAlliances:          []tAllianceID{"a1"},
+		}))
+		_, err = r.GetCity(ctx, "c1", "p1")
+		mustNoRows(t, err)
+		_, err = r.GetMovement(ctx, "m1", "p1")
+		mustNoRows(t, err)
+		_, err = r.GetAlliance(ctx, "a1")
+		mustNoRows(t, err)
+		unitPage, err := r.ListUnitQueueItems(ctx, "c1", "p1", "", 10)
+		mustNoErr(t, err)
+		buildingPage, err := r.ListBuildingQueueItems(ctx, "c1", "p1", "", 10)
+		mustNoErr(t, err)
+		researchPage, err := r.ListResearchQueueItems(ctx, "c2", "p1", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(unitPage)+len(buildingPage)+len(researchPage), 0)
+	})
+}
+
+func TestUpsertViewsIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	r := newTestSQLiteRepository(t)
+	_, err := r.db.Exec("DROP TABLE players")
+	mustNoErr(t, err)
+
+	err = r.UpsertViews(ctx, &dbViews{
+		cities:  []*dbCity{{id: "c1", name: "one", playerID: "p1", buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"}},
+		players: []*dbPlayer{{id: "p1"}},
+	})
+	if err == nil {
+		t.Fatal("expected the players upsert to fail")
+	}
+	_, err = r.GetCityInfo(ctx, "c1")
+	mustNoRows(t, err)
+
+	// system mail is part of the views, it is not delivered for views that never land
+	r = newTestSQLiteRepository(t)
+	_, err = r.db.Exec("DROP TABLE mail_inbox")
+	mustNoErr(t, err)
+	err = r.UpsertViews(ctx, &dbViews{
+		cities: []*dbCity{{id: "c1", name: "one", playerID: "p1", buildingsLevel: "{}", resourceBase: "{}", unitCount: "{}", reinforcements: "{}"}},
+		mails:  []*dbMailDelivery{{mail: &dbMail{id: "battle-e1", subject: "Battle report: one", sentEpoch: 1}, recipients: []tPlayerID{"p1"}}},
+	})
+	if err == nil {
+		t.Fatal("expected the inbox insert to fail")
+	}
+	_, err = r.GetCityInfo(ctx, "c1")
+	mustNoRows(t, err)
+	var mails int
+	mustNoErr(t, r.db.QueryRow("SELECT COUNT(*) FROM mail_messages").Scan(&mails))
+	mustEqual(t, mails, 0)
+}
+
+func TestRepositoryMail(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		m1 := &dbMail{id: "m1", senderID: "p1", recipientID: "p2", subject: "hi", content: "hello", sentEpoch: 1}
+		m2 := &dbMail{id: "m2", senderID: "p2", allianceID: "a1", subject: "all", content: "hello all", sentEpoch: 2}
+		mustNoErr(t, r.InsertMail(ctx, m1, []tPlayerID{"p2"}))
+		mustNoErr(t, r.InsertMail(ctx, m2, []tPlayerID{"p1", "p2", "p3"}))
+		// a mail with the same ID is not delivered, not even to new recipients
+		forged := &dbMail{id: "m1", senderID: "p3", recipientID: "p4", subject: "forged", content: "hello", sentEpoch: 3}
+		err := r.InsertMail(ctx, forged, []tPlayerID{"p4"})
+		if !errors.Is(err, errAlreadyExists) {
+			t.Fatalf("got %v, want %v", err, errAlreadyExists)
+		}
+		_, err = r.GetMail(ctx, "m1", "p4")
+		mustNoRows(t, err)
+
+		got, err := r.GetMail(ctx, "m1", "p2")
+		mustNoErr(t, err)
+		mustEqual(t, got, m1)
+		got, err = r.GetMail(ctx, "m1", "p1")
+		mustNoErr(t, err)
+		mustEqual(t, got.read, true)
+		_, err = r.GetMail(ctx, "m1", "p3")
+		mustNoRows(t, err)
+
+		inbox, err := r.ListInbox(ctx, "p2", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, inbox, []*dbMail{m1, m2})
+		inbox, err = r.ListInbox(ctx, "p2", "m1", 10)
+		mustNoErr(t, err)
+		mustEqual(t, inbox, []*dbMail{m2})
+		outbox, err := r.ListOutbox(ctx, "p2", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(outbox), 1)
+		mustEqual(t, outbox[0].read, true)
+
+		mustNoErr(t, r.MarkMailRead(ctx, "m1", "p2"))
+		got, err = r.GetMail(ctx, "m1", "p2")
+		mustNoErr(t, err)
+		mustEqual(t, got.read, true)
+		mustNoRows(t, r.MarkMailRead(ctx, "m1", "p1"))
+
+		// the sender deletes the mail from the outbox, the recipients still have it
+		mustNoErr(t, r.DeleteMail(ctx, "m2", "p2"))
+		outbox, err = r.ListOutbox(ctx, "p2", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(outbox), 0)
+		inbox, err = r.ListInbox(ctx, "p2", "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(inbox), 1)
+		_, err = r.GetMail(ctx, "m2", "p1")
+		mustNoErr(t, err)
+		mustNoRows(t, r.DeleteMail(ctx, "m2", "p4"))
+		mustNoErr(t, r.DeleteMail(ctx, "m2", "p1"))
+		_, err = r.GetMail(ctx, "m2", "p1")
+		mustNoRows(t, err)
+		mustNoRows(t, r.MarkMailRead(ctx, "m2", "p1"))
+	})
+}
+
+func TestRepositoryConfigVersions(t *testing.T) {
+	runConformance(t, func(t *testing.T, r conformanceRepository) {
+		ctx := context.Background()
+		versions := []*dbConfigVersion{
+			{version: 2, effectiveFrom: 20, sourceHash: "b", config: "{}"},
+			{version: 1, effectiveFrom: 10, sourceHash: "a", config: "{}"},
+		}
+		for _, v := range versions {
+			mustNoErr(t, r.InsertConfigVersion(ctx, v))
+		}
+		if r.InsertConfigVersion(ctx, versions[0]) == nil {
+			t.Fatal("expected an error on a duplicate version")
+		}
+
+		got, err := r.ListConfigVersions(ctx)
+		mustNoErr(t, err)
+		mustEqual(t, got, []*dbConfigVersion{versions[1], versions[0]})
+	})
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// NewRouter serves every world under the /worlds/{worldid} prefix, and the
+// default world also without it so that existing clients keep working.
+func NewRouter(defaultWorldID string, worldRouters map[string]func(chi.Router)) http.Handler {
+	router := chi.NewRouter()
+
+	// middleware
+	router.Use(middleware.RequestID)
+	router.Use(middleware.RealIP)
+	router.Use(middleware.Logger)
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.Timeout(60 * time.Second))
+	router.Use(WithAuthentication)
+	router.Use(WithPagination)
+
+	// routes
+	router.Route(fmt.Sprintf("/%s", APIVersion), func(router chi.Router) {
+		if defaultWorld, ok := worldRouters[defaultWorldID]; ok {
+			defaultWorld(router)
+		}
+		router.Route("/worlds", func(router chi.Router) {
+			for worldID, worldRouter := range worldRouters {
+				router.Route(fmt.Sprintf("/%s", worldID), worldRouter)
+			}
+		})
+	})
+	return router
+}
+
+// WorldRoutes are all the routes of a single world, relative to its prefix.
+func WorldRoutes(handlers *ServerHandler, adminToken string) func(chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/", handlers.GetWelcome)
+		router.Route("/cities", func(router chi.Router) {
+			router.Get("/", handlers.ListCityInfo)
+			router.Post("/", handlers.CreateCity)
+			router.With(WithCityIDContext).Route(fmt.Sprintf("/{%s}", CityID), func(router chi.Router) {
+				router.Get("/", handlers.GetCity)
+				router.Delete("/", handlers.DeleteCity)
+				router.Get("/info", handlers.GetCityInfo)
+				router.Post("/reinforcements/recall", handlers.RecallReinforcements)
+				router.Route("/unitqitems", func(router chi.Router) {
+					router.Get("/", handlers.ListUnitQueueItem)
+					router.Post("/", handlers.QueueUnit)
+					router.With(WithUnitQueueItemIDContext).Route(fmt.Sprintf("/{%s}", ItemID), func(router chi.Router) {
+						router.Get("/", handlers.GetUnitQueueItem)
+					})
+				})
+				router.Route("/buildingqitems", func(router chi.Router) {
+					router.Get("/", handlers.ListBuildingQueueItems)
+					router.Post("/", handlers.QueueBuilding)
+					router.With(WithBuildingQueueItemIDContext).Route(fmt.Sprintf("/{%s}", ItemID), func(router chi.Router) {
+						router.Get("/", handlers.GetBuildingQueueItem)
+					})
+				})
+				router.Route("/researchqitems", func(router chi.Router) {
+					router.Get("/", handlers.ListResearchQueueItems)
+					router.Post("/", handlers.QueueResearch)
+				})
+			})
+		})
+		router.Get("/research", handlers.GetPlayerResearch)
+		router.Route("/config", func(router chi.Router) {
+			router.Get("/", handlers.GetConfig)
+			router.Get("/requirements", handlers.GetRequirements)
+		})
+		router.Route("/admin", func(router chi.Router) {
+			router.Use(WithAdminToken(adminToken))
+			router.Post("/config", handlers.ActivateConfig)
+			if DevBuild {
+				router.Post("/debug/clock", handlers.AdvanceClock)
+			}
+		})
+		router.Route("/movements", func(router chi.Router) {
+			router.Use(WithPagination)
+			router.Get("/", handlers.ListMovements)
+			router.Post("/", handlers.StartMovement)
+
+			router.Route(fmt.Sprintf("/{%s}", MovementID), func(router chi.Router) {
+				router.Use(WithMovementIDContext)
+				router.Get("/", handlers.GetMovement)
+			})
+		})
+		router.Route("/alliances", func(router chi.Router) {
+			router.Get("/", handlers.ListAllianceInfo)
+			router.Post("/", handlers.CreateAlliance)
+			router.With(WithAllianceIDContext).Route(fmt.Sprintf("/{%s}", AllianceID), func(router chi.Router) {
+				router.Get("/", handlers.GetAlliance)
+				router.Post("/invites", handlers.InviteToAlliance)
+				router.Post("/join", handlers.JoinAlliance)
+				router.Post("/leave", handlers.LeaveAlliance)
+				router.With(WithMemberIDContext).Delete(fmt.Sprintf("/members/{%s}", MemberID), handlers.KickFromAlliance)
+			})
+		})
+		router.Route("/market/offers", func(router chi.Router) {
+			router.Get("/", handlers.ListTradeOffers)
+			router.Post("/", handlers.CreateTradeOffer)
+			router.With(WithTradeOfferIDContext).Route(fmt.Sprintf("/{%s}", OfferID), func(router chi.Router) {
+				router.Get("/", handlers.GetTradeOffer)
+				router.Post("/accept", handlers.AcceptTradeOffer)
+				router.Post("/cancel", handlers.CancelTradeOffer)
+			})
+		})
+		router.Post("/market/exchange", handlers.ExchangeResources)
+		router.Route("/mail", func(router chi.Router) {
+			router.Post("/", handlers.SendMail)
+			router.Get("/inbox", handlers.ListInbox)
+			router.Get("/outbox", handlers.ListOutbox)
+			router.With(WithMailIDContext).Route(fmt.Sprintf("/{%s}", MailID), func(router chi.Router) {
+				router.Get("/", handlers.GetMail)
+				router.Delete("/", handlers.DeleteMail)
+				router.Post("/read", handlers.MarkMailRead)
+			})
+		})
+		router.Get("/map", handlers.GetMap)
+		router.With(WithLeaderboardKindContext).Get(fmt.Sprintf("/leaderboards/{%s}", Kind), handlers.ListLeaderboard)
+	}
+}
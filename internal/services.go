@@ -2,30 +2,51 @@ package internal
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
-	"time"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// thrown when the request is well formed but cannot be fulfilled for the player
+	// e.g., send mail to an alliance the player does not belong to
+	errInvalidRequest = fmt.Errorf("invalid request")
+)
+
 type viewerRepository interface {
 	GetCity(ctx context.Context, id, playerID string) (*dbCity, error)
 	GetCityInfo(ctx context.Context, id string) (*dbCity, error)
 	ListCityInfo(ctx context.Context, lastID string, pageSize int, filters ...listCityInfoFilterOpt) ([]*dbCity, error)
+	ListCityInfoNear(ctx context.Context, x, y tCoordinate, radius int, lastID string, pageSize int, filters ...listCityInfoFilterOpt) ([]*dbCity, error)
 	GetMovement(ctx context.Context, id, playerID string) (*dbMovement, error)
 	ListMovements(ctx context.Context, playerID, lastID string, pageSize int, filters ...listMovementsFilterOpt) ([]*dbMovement, error)
 	GetUnitQueueItem(ctx context.Context, id, cityID, playerID string) (*dbUnitQueueItem, error)
 	ListUnitQueueItems(ctx context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbUnitQueueItem, error)
 	GetBuildingQueueItem(ctx context.Context, id, cityID, playerID string) (*dbBuildingQueueItem, error)
 	ListBuildingQueueItems(ctx context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbBuildingQueueItem, error)
+	GetAlliance(ctx context.Context, id string) (*dbAlliance, error)
+	ListAllianceInfo(ctx context.Context, lastID string, pageSize int) ([]*dbAlliance, error)
+	ListResearchQueueItems(ctx context.Context, cityID, playerID, lastID string, pageSize int) ([]*dbResearchQueueItem, error)
+	GetPlayerResearch(ctx context.Context, playerID string) (string, error)
+	ListLeaderboard(ctx context.Context, kind tLeaderboardKind, lastID string, pageSize int) ([]*dbLeaderboardEntry, error)
+	GetTradeOffer(ctx context.Context, id string) (*dbTradeOffer, error)
+	ListTradeOffers(ctx context.Context, lastID string, pageSize int) ([]*dbTradeOffer, error)
 }
 
 type viewerService struct {
 	repository viewerRepository
 }
 
+// GetCity returns the complete city state if it belongs to the player or to
+// one of its alliance members.
 func (s *viewerService) GetCity(ctx context.Context, id, playerID string) (*city, error) {
-	dbCity, err := s.repository.GetCityInfo(ctx, id)
+	dbCity, err := s.repository.GetCity(ctx, id, playerID)
 	if err != nil {
 		return nil, err
 	}
@@ -37,31 +58,78 @@ func (s *viewerService) GetCityInfo(ctx context.Context, id string) (*city, erro
 	if err != nil {
 		return nil, err
 	}
-	return cityFromDBModel(dbCity)
+	return cityInfoFromDBModel(dbCity), nil
 }
 
-func (s *viewerService) ListCityInfo(ctx context.Context, lastID string, pageSize int, playerIDFilter string, locationBoundsFilter string) ([]*city, error) {
+// ListCityInfo lists the cities by ID, or nearest first when near is set,
+// with any of the filters set. The locations are comma separated, e.g., the
+// bounds are x1,y1,x2,y2 and near is x,y.
+func (s *viewerService) ListCityInfo(ctx context.Context, lastID string, pageSize int, playerIDFilter, locationBoundsFilter, nearFilter, radiusFilter string) ([]*city, error) {
 	additionalFilters := make([]listCityInfoFilterOpt, 0)
 	if playerIDFilter != "" {
 		additionalFilters = append(additionalFilters, withPlayerID(playerIDFilter))
 	}
 	if locationBoundsFilter != "" {
-		// TODO: fix this
+		bounds, err := parseCoordinates(locationBoundsFilter, 4)
+		if err != nil {
+			return nil, fmt.Errorf("%w: locationbounds %s", errInvalidRequest, err.Error())
+		}
+		additionalFilters = append(additionalFilters, withinLocation(
+			min(bounds[0], bounds[2]),
+			min(bounds[1], bounds[3]),
+			max(bounds[0], bounds[2]),
+			max(bounds[1], bounds[3]),
+		)...)
+	}
+	radius := -1
+	if radiusFilter != "" {
+		var err error
+		radius, err = strconv.Atoi(radiusFilter)
+		if err != nil || radius < 0 || radius > math.MaxInt32 {
+			return nil, fmt.Errorf("%w: radius must be an integer between 0 and %d", errInvalidRequest, math.MaxInt32)
+		}
+		if nearFilter == "" {
+			return nil, fmt.Errorf("%w: radius requires near", errInvalidRequest)
+		}
 	}
 
-	dbCities, err := s.repository.ListCityInfo(ctx, lastID, pageSize, additionalFilters...)
+	var (
+		dbCities []*dbCity
+		err      error
+	)
+	if nearFilter != "" {
+		near, parseErr := parseCoordinates(nearFilter, 2)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: near %s", errInvalidRequest, parseErr.Error())
+		}
+		dbCities, err = s.repository.ListCityInfoNear(ctx, tCoordinate(near[0]), tCoordinate(near[1]), radius, lastID, pageSize, additionalFilters...)
+	} else {
+		dbCities, err = s.repository.ListCityInfo(ctx, lastID, pageSize, additionalFilters...)
+	}
 	if err != nil {
 		return nil, err
 	}
 	cities := make([]*city, len(dbCities))
 	for i := 0; i < len(dbCities); i++ {
-		city, err := cityFromDBModel(dbCities[i])
+		cities[i] = cityInfoFromDBModel(dbCities[i])
+	}
+	return cities, nil
+}
+
+func parseCoordinates(raw string, count int) ([]int, error) {
+	values := strings.Split(raw, ",")
+	if len(values) != count {
+		return nil, fmt.Errorf("expected %d comma separated integers", count)
+	}
+	coordinates := make([]int, count)
+	for i, value := range values {
+		coordinate, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("expected %d comma separated integers", count)
 		}
-		cities[i] = city
+		coordinates[i] = int(coordinate)
 	}
-	return cities, nil
+	return coordinates, nil
 }
 
 func (s *viewerService) GetMovement(ctx context.Context, id, playerID string) (*movement, error) {
@@ -140,21 +208,251 @@ func (s *viewerService) ListBuildingQueueItems(ctx context.Context, cityID, play
 
 }
 
+func (s *viewerService) GetAlliance(ctx context.Context, id string) (*alliance, error) {
+	dbAlliance, err := s.repository.GetAlliance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return allianceFromDBModel(dbAlliance)
+}
+
+func (s *viewerService) ListAllianceInfo(ctx context.Context, lastID string, pageSize int) ([]*alliance, error) {
+	dbAlliances, err := s.repository.ListAllianceInfo(ctx, lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	alliances := make([]*alliance, len(dbAlliances))
+	for i := 0; i < len(dbAlliances); i++ {
+		alliance, err := allianceFromDBModel(dbAlliances[i])
+		if err != nil {
+			return nil, err
+		}
+		alliances[i] = alliance
+	}
+	return alliances, nil
+}
+
+func (s *viewerService) GetTradeOffer(ctx context.Context, id string) (*tradeOffer, error) {
+	dbOffer, err := s.repository.GetTradeOffer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tradeOfferFromDBModel(dbOffer)
+}
+
+// ListTradeOffers lists the open trade offers of every player, the market is
+// the same for all of them.
+func (s *viewerService) ListTradeOffers(ctx context.Context, lastID string, pageSize int) ([]*tradeOffer, error) {
+	dbOffers, err := s.repository.ListTradeOffers(ctx, lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	offers := make([]*tradeOffer, len(dbOffers))
+	for i := 0; i < len(dbOffers); i++ {
+		offer, err := tradeOfferFromDBModel(dbOffers[i])
+		if err != nil {
+			return nil, err
+		}
+		offers[i] = offer
+	}
+	return offers, nil
+}
+
+func (s *viewerService) ListResearchQueueItems(ctx context.Context, cityID, playerID, lastID string, pageSize int) ([]*researchQueueItem, error) {
+	dbItems, err := s.repository.ListResearchQueueItems(ctx, cityID, playerID, lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*researchQueueItem, len(dbItems))
+	for i := 0; i < len(dbItems); i++ {
+		items[i] = researchQueueItemFromDBModel(dbItems[i])
+	}
+	return items, nil
+}
+
+func (s *viewerService) GetPlayerResearch(ctx context.Context, playerID string) ([]tResearchName, error) {
+	research, err := s.repository.GetPlayerResearch(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return playerResearchFromDBModel(research)
+}
+
+func (s *viewerService) ListLeaderboard(ctx context.Context, kind, lastID string, pageSize int) ([]*leaderboardEntry, error) {
+	switch tLeaderboardKind(kind) {
+	case overallLeaderboard, attackLeaderboard, defenceLeaderboard, allianceLeaderboard:
+	default:
+		return nil, fmt.Errorf("%w: unknown leaderboard %s", errInvalidRequest, kind)
+	}
+
+	dbEntries, err := s.repository.ListLeaderboard(ctx, tLeaderboardKind(kind), lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*leaderboardEntry, len(dbEntries))
+	for i := 0; i < len(dbEntries); i++ {
+		entries[i] = leaderboardEntryFromDBModel(dbEntries[i])
+	}
+	return entries, nil
+}
+
+// The map is read from the in-memory state rather than the views, bounded so
+// that a single request does not go through the whole world.
+const (
+	maxMapTiles   = 128 * 128
+	maxMapRegions = 64 * 64
+)
+
+type mapService struct {
+	eventSourcer eventSourcer
+	configs      *ConfigHistory
+}
+
+func (s *mapService) GetMap(x1, y1, x2, y2 tCoordinate, zoomedOut bool) ([]*mapTile, []*mapRegion, error) {
+	x1, x2 = min(x1, x2), max(x1, x2)
+	y1, y2 = min(y1, y2), max(y1, y2)
+	// there is nothing to show beyond the edges of a bounded world
+	if size := s.configs.Latest().World.Size; size > 0 {
+		if x1 > size || y1 > size || x2 < -size || y2 < -size {
+			return []*mapTile{}, []*mapRegion{}, nil
+		}
+		x1, y1 = max(x1, -size), max(y1, -size)
+		x2, y2 = min(x2, size), min(y2, size)
+	}
+	// NOTE: the spans are widened before subtracting, extreme bounds overflow
+	if zoomedOut {
+		from, to := regionOf(x1, y1), regionOf(x2, y2)
+		if (int64(to.x)-int64(from.x)+1)*(int64(to.y)-int64(from.y)+1) > maxMapRegions {
+			return nil, nil, fmt.Errorf("%w: the map spans more than %d regions", errInvalidRequest, maxMapRegions)
+		}
+		return []*mapTile{}, s.eventSourcer.mapRegions(x1, y1, x2, y2), nil
+	}
+	if (int64(x2)-int64(x1)+1)*(int64(y2)-int64(y1)+1) > maxMapTiles {
+		return nil, nil, fmt.Errorf("%w: the map spans more than %d tiles, zoom out", errInvalidRequest, maxMapTiles)
+	}
+	return s.eventSourcer.mapTiles(x1, y1, x2, y2), []*mapRegion{}, nil
+}
+
+type mailRepository interface {
+	InsertMail(ctx context.Context, m *dbMail, recipients []tPlayerID) error
+	GetMail(ctx context.Context, id, playerID string) (*dbMail, error)
+	ListInbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error)
+	ListOutbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*dbMail, error)
+	MarkMailRead(ctx context.Context, id, playerID string) error
+	DeleteMail(ctx context.Context, id, playerID string) error
+	GetAlliance(ctx context.Context, id string) (*dbAlliance, error)
+	GetPlayer(ctx context.Context, id string) (*dbPlayer, error)
+}
+
+// Mail is not part of the game state, so it is stored directly instead of
+// going through the event sourcing.
+type mailService struct {
+	repository mailRepository
+	clock      Clock
+}
+
+func (s *mailService) SendMail(ctx context.Context, playerID string, m *mail) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	var recipients []tPlayerID
+	switch {
+	case m.allianceID != "" && m.recipientID != "":
+		return fmt.Errorf("%w: mail either a player or an alliance", errInvalidRequest)
+	case m.allianceID != "":
+		dbAlliance, err := s.repository.GetAlliance(ctx, string(m.allianceID))
+		if err != nil {
+			return err
+		}
+		isMember := false
+		for _, member := range dbAlliance.members {
+			isMember = isMember || member == tPlayerID(playerID)
+		}
+		if !isMember {
+			return fmt.Errorf("%w: only members can mail the alliance", errInvalidRequest)
+		}
+		recipients = dbAlliance.members
+	case m.recipientID != "":
+		_, err := s.repository.GetPlayer(ctx, string(m.recipientID))
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: no player %s to mail", errInvalidRequest, m.recipientID)
+		}
+		if err != nil {
+			return err
+		}
+		recipients = []tPlayerID{m.recipientID}
+	default:
+		return fmt.Errorf("%w: mail without recipients", errInvalidRequest)
+	}
+
+	// important: these values cannot be trusted from the API
+	// set them on the server side based on token / internal clock
+	m.senderID = tPlayerID(playerID)
+	m.sentEpoch = serverSideEpoch
+	m.read = false
+
+	return s.repository.InsertMail(ctx, mailToDBModel(m), recipients)
+}
+
+func (s *mailService) GetMail(ctx context.Context, id, playerID string) (*mail, error) {
+	dbMail, err := s.repository.GetMail(ctx, id, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return mailFromDBModel(dbMail), nil
+}
+
+func (s *mailService) ListInbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*mail, error) {
+	dbMails, err := s.repository.ListInbox(ctx, playerID, lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	mails := make([]*mail, len(dbMails))
+	for i := 0; i < len(dbMails); i++ {
+		mails[i] = mailFromDBModel(dbMails[i])
+	}
+	return mails, nil
+}
+
+func (s *mailService) ListOutbox(ctx context.Context, playerID, lastID string, pageSize int) ([]*mail, error) {
+	dbMails, err := s.repository.ListOutbox(ctx, playerID, lastID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	mails := make([]*mail, len(dbMails))
+	for i := 0; i < len(dbMails); i++ {
+		mails[i] = mailFromDBModel(dbMails[i])
+	}
+	return mails, nil
+}
+
+func (s *mailService) MarkMailRead(ctx context.Context, id, playerID string) error {
+	return s.repository.MarkMailRead(ctx, id, playerID)
+}
+
+func (s *mailService) DeleteMail(ctx context.Context, id, playerID string) error {
+	return s.repository.DeleteMail(ctx, id, playerID)
+}
+
 type eventSourcer interface {
 	queueEventHandling(e *event)
+	mapTiles(x1, y1, x2, y2 tCoordinate) []*mapTile
+	mapRegions(x1, y1, x2, y2 tCoordinate) []*mapRegion
 }
 
 type eventInserter interface {
 	InsertEvent(ctx context.Context, e *event) error
+	GetCity(ctx context.Context, id, playerID string) (*dbCity, error)
 }
 
 type inserterService struct {
 	repository   eventInserter
 	eventSourcer eventSourcer
+	configs      *ConfigHistory
+	clock        Clock
 }
 
 func (s *inserterService) StartMovement(ctx context.Context, playerID string, m *movement) error {
-	serverSideEpoch := tSec(time.Now().Unix())
+	serverSideEpoch := tSec(s.clock.Now().Unix())
 
 	// important: these values cannot be trusted from the API
 	// set them on the server side based on token / internal clock
@@ -168,6 +466,7 @@ func (s *inserterService) StartMovement(ctx context.Context, playerID string, m
 		DepartureEpoch: serverSideEpoch,
 		UnitCount:      m.unitCount,
 		ResourceCount:  m.resourceCount,
+		MovementType:   m.movementType,
 	}
 
 	payload, err := json.Marshal(startMovement)
@@ -191,7 +490,17 @@ func (s *inserterService) StartMovement(ctx context.Context, playerID string, m
 }
 
 func (s *inserterService) QueueUnit(ctx context.Context, playerID string, item *unitQueueItem) error {
-	serverSideEpoch := tSec(time.Now().Unix())
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	// fail early on requirements, the event sourcer will check them again on processing
+	c, err := s.getCity(ctx, item.cityID, playerID)
+	if err != nil {
+		return err
+	}
+	err = s.configs.Latest().checkUnitRequirements(item.unitType, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidRequest, err.Error())
+	}
 
 	queueItem := queueUnitEvent{
 		UnitQueueItemID: tUnitQueueItemID(item.id),
@@ -221,8 +530,61 @@ func (s *inserterService) QueueUnit(ctx context.Context, playerID string, item *
 	return nil
 }
 
+// getCity is a city of the player to give commands to. Allies can see it, but
+// it is not theirs to command.
+func (s *inserterService) getCity(ctx context.Context, cityID tCityID, playerID string) (*city, error) {
+	dbCity, err := s.repository.GetCity(ctx, string(cityID), playerID)
+	if err != nil {
+		return nil, err
+	}
+	if string(dbCity.playerID) != playerID {
+		return nil, fmt.Errorf("getCity: %w", sql.ErrNoRows)
+	}
+	return cityFromDBModel(dbCity)
+}
+
+func (s *inserterService) QueueResearch(ctx context.Context, playerID string, item *researchQueueItem) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	queueItem := queueResearchEvent{
+		ResearchQueueItemID: item.id,
+		CityID:              item.cityID,
+		PlayerID:            tPlayerID(playerID),
+		ResearchName:        item.researchName,
+	}
+	payload, err := json.Marshal(queueItem)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    queueResearchEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
 func (s *inserterService) QueueBuilding(ctx context.Context, playerID string, item *buildingQueueItem) error {
-	serverSideEpoch := tSec(time.Now().Unix())
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	// fail early on requirements, the event sourcer will check them again on processing
+	c, err := s.getCity(ctx, item.cityID, playerID)
+	if err != nil {
+		return err
+	}
+	err = s.configs.Latest().checkBuildingRequirements(item.targetBuilding, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidRequest, err.Error())
+	}
 
 	queueItem := queueBuildingEvent{
 		BuildingQueueItemID: item.id,
@@ -253,7 +615,13 @@ func (s *inserterService) QueueBuilding(ctx context.Context, playerID string, it
 }
 
 func (s *inserterService) CreateCity(ctx context.Context, playerID string, c *city) error {
-	serverSideEpoch := tSec(time.Now().Unix())
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	// fail early on the placement, the event sourcer will check it again on processing
+	err := s.configs.Latest().checkLocation(c.locationX, c.locationY)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidRequest, err.Error())
+	}
 
 	createCity := createCityEvent{
 		CityID:    tCityID(c.id),
@@ -290,7 +658,7 @@ func (s *inserterService) CreateCity(ctx context.Context, playerID string, c *ci
 }
 
 func (s *inserterService) DeleteCity(ctx context.Context, playerID, cityID string) error {
-	serverSideEpoch := tSec(time.Now().Unix())
+	serverSideEpoch := tSec(s.clock.Now().Unix())
 
 	deleteCity := deleteCityEvent{
 		PlayerID: tPlayerID(playerID),
@@ -304,7 +672,37 @@ func (s *inserterService) DeleteCity(ctx context.Context, playerID, cityID strin
 	eventID := tEventID(uuid.NewString())
 	e := &event{
 		id:      eventID,
-		name:    createCityEventName,
+		name:    deleteCityEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+
+	return nil
+}
+
+func (s *inserterService) CreateAlliance(ctx context.Context, playerID string, a *alliance) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	createAlliance := createAllianceEvent{
+		AllianceID: a.id,
+		Name:       a.name,
+		PlayerID:   tPlayerID(playerID),
+	}
+	payload, err := json.Marshal(createAlliance)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    createAllianceEventName,
 		epoch:   serverSideEpoch,
 		payload: string(payload),
 	}
@@ -314,6 +712,303 @@ func (s *inserterService) DeleteCity(ctx context.Context, playerID, cityID strin
 		return err
 	}
 	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) InviteToAlliance(ctx context.Context, playerID, allianceID, inviteeID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	inviteToAlliance := inviteToAllianceEvent{
+		AllianceID: tAllianceID(allianceID),
+		PlayerID:   tPlayerID(playerID),
+		InviteeID:  tPlayerID(inviteeID),
+	}
+	payload, err := json.Marshal(inviteToAlliance)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    inviteToAllianceEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
 
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) JoinAlliance(ctx context.Context, playerID, allianceID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	joinAlliance := joinAllianceEvent{
+		AllianceID: tAllianceID(allianceID),
+		PlayerID:   tPlayerID(playerID),
+	}
+	payload, err := json.Marshal(joinAlliance)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    joinAllianceEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) LeaveAlliance(ctx context.Context, playerID, allianceID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	leaveAlliance := leaveAllianceEvent{
+		AllianceID: tAllianceID(allianceID),
+		PlayerID:   tPlayerID(playerID),
+	}
+	payload, err := json.Marshal(leaveAlliance)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    leaveAllianceEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) KickFromAlliance(ctx context.Context, playerID, allianceID, memberID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	kickFromAlliance := kickFromAllianceEvent{
+		AllianceID: tAllianceID(allianceID),
+		PlayerID:   tPlayerID(playerID),
+		MemberID:   tPlayerID(memberID),
+	}
+	payload, err := json.Marshal(kickFromAlliance)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    kickFromAllianceEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) CreateTradeOffer(ctx context.Context, playerID string, o *tradeOffer) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	// fail early on a city without merchants, the event sourcer will check how many are free on processing
+	err := s.checkMerchants(ctx, o.cityID, playerID)
+	if err != nil {
+		return err
+	}
+
+	createTradeOffer := createTradeOfferEvent{
+		TradeOfferID: o.id,
+		PlayerID:     tPlayerID(playerID),
+		CityID:       o.cityID,
+		Offered:      o.offered,
+		Requested:    o.requested,
+	}
+	payload, err := json.Marshal(createTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    createTradeOfferEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) AcceptTradeOffer(ctx context.Context, playerID, tradeOfferID, cityID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	err := s.checkMerchants(ctx, tCityID(cityID), playerID)
+	if err != nil {
+		return err
+	}
+
+	acceptTradeOffer := acceptTradeOfferEvent{
+		TradeOfferID: tTradeOfferID(tradeOfferID),
+		PlayerID:     tPlayerID(playerID),
+		CityID:       tCityID(cityID),
+	}
+	payload, err := json.Marshal(acceptTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    acceptTradeOfferEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) CancelTradeOffer(ctx context.Context, playerID, tradeOfferID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	cancelTradeOffer := cancelTradeOfferEvent{
+		TradeOfferID: tTradeOfferID(tradeOfferID),
+		PlayerID:     tPlayerID(playerID),
+	}
+	payload, err := json.Marshal(cancelTradeOffer)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    cancelTradeOfferEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) ExchangeResources(ctx context.Context, playerID, cityID string, from, to tResourceName, count tResourceCount) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	// fail early on requirements and rates, the event sourcer will check them again on processing
+	c, err := s.getCity(ctx, tCityID(cityID), playerID)
+	if err != nil {
+		return err
+	}
+	cfg := s.configs.Latest()
+	err = checkRequiredBuildings(cfg.Market.Exchange.RequiredBuildings, c.buildingsLevel)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidRequest, err.Error())
+	}
+	_, err = cfg.exchange(from, to, count)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidRequest, err.Error())
+	}
+
+	exchangeResources := exchangeResourcesEvent{
+		PlayerID: tPlayerID(playerID),
+		CityID:   tCityID(cityID),
+		From:     from,
+		To:       to,
+		Count:    count,
+	}
+	payload, err := json.Marshal(exchangeResources)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    exchangeResourcesEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) RecallReinforcements(ctx context.Context, playerID, cityID string) error {
+	serverSideEpoch := tSec(s.clock.Now().Unix())
+
+	recallReinforcements := recallReinforcementsEvent{
+		PlayerID: tPlayerID(playerID),
+		CityID:   tCityID(cityID),
+	}
+	payload, err := json.Marshal(recallReinforcements)
+	if err != nil {
+		return err
+	}
+
+	eventID := tEventID(uuid.NewString())
+	e := &event{
+		id:      eventID,
+		name:    recallReinforcementsEventName,
+		epoch:   serverSideEpoch,
+		payload: string(payload),
+	}
+
+	err = s.repository.InsertEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	s.eventSourcer.queueEventHandling(e)
+	return nil
+}
+
+func (s *inserterService) checkMerchants(ctx context.Context, cityID tCityID, playerID string) error {
+	c, err := s.getCity(ctx, cityID, playerID)
+	if err != nil {
+		return err
+	}
+	if s.configs.Latest().merchantCapacity(c.buildingsLevel) == 0 {
+		return fmt.Errorf("%w: %s", errInvalidRequest, "the city has no merchants")
+	}
 	return nil
 }
@@ -1 +1,74 @@
 package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMailServiceSendMail(t *testing.T) {
+	ctx := context.Background()
+	repository := newInMemoryRepository()
+	for _, playerID := range []tPlayerID{"p1", "p2", "p3"} {
+		mustNoErr(t, repository.UpsertPlayer(ctx, &dbPlayer{id: playerID}))
+	}
+	s := &mailService{repository: repository, clock: NewFakeClock(time.Unix(1_000_000, 0))}
+
+	mustNoErr(t, s.SendMail(ctx, "p1", &mail{id: "m1", recipientID: "p2", subject: "hi", content: "hello"}))
+	got, err := s.GetMail(ctx, "m1", "p2")
+	mustNoErr(t, err)
+	mustEqual(t, got.senderID, tPlayerID("p1"))
+
+	// the recipient cannot pass the mail on as if it was sent by someone else
+	err = s.SendMail(ctx, "p2", &mail{id: "m1", recipientID: "p3", subject: "forged", content: "hello"})
+	if !errors.Is(err, errAlreadyExists) {
+		t.Fatalf("got %v, want %v", err, errAlreadyExists)
+	}
+	_, err = s.GetMail(ctx, "m1", "p3")
+	mustNoRows(t, err)
+	got, err = s.GetMail(ctx, "m1", "p2")
+	mustNoErr(t, err)
+	mustEqual(t, got.subject, "hi")
+
+	// nobody to deliver the mail to
+	err = s.SendMail(ctx, "p1", &mail{id: "m2", recipientID: "p9", subject: "hi", content: "hello"})
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	_, err = s.GetMail(ctx, "m2", "p1")
+	mustNoRows(t, err)
+}
+
+func TestMailServiceSendAllianceMail(t *testing.T) {
+	ctx := context.Background()
+	repository := newInMemoryRepository()
+	mustNoErr(t, repository.UpsertAlliance(ctx, &dbAlliance{id: "a1", name: "allies", leaderID: "p1", invites: "{}"}))
+	for _, playerID := range []tPlayerID{"p1", "p2"} {
+		mustNoErr(t, repository.UpsertPlayer(ctx, &dbPlayer{id: playerID, allianceID: "a1"}))
+	}
+	mustNoErr(t, repository.UpsertPlayer(ctx, &dbPlayer{id: "p3"}))
+	s := &mailService{repository: repository, clock: NewFakeClock(time.Unix(1_000_000, 0))}
+
+	// every member gets the mail, the sender included
+	mustNoErr(t, s.SendMail(ctx, "p2", &mail{id: "m1", allianceID: "a1", subject: "hi", content: "hello all"}))
+	for _, playerID := range []string{"p1", "p2"} {
+		inbox, err := s.ListInbox(ctx, playerID, "", 10)
+		mustNoErr(t, err)
+		mustEqual(t, len(inbox), 1)
+		mustEqual(t, inbox[0].senderID, tPlayerID("p2"))
+	}
+
+	// only the members mail the alliance, and not along with a player
+	err := s.SendMail(ctx, "p3", &mail{id: "m2", allianceID: "a1", subject: "hi", content: "hello"})
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	err = s.SendMail(ctx, "p1", &mail{id: "m3", allianceID: "a1", recipientID: "p2", subject: "hi", content: "hello"})
+	if !errors.Is(err, errInvalidRequest) {
+		t.Fatalf("got %v, want %v", err, errInvalidRequest)
+	}
+	inbox, err := s.ListInbox(ctx, "p1", "", 10)
+	mustNoErr(t, err)
+	mustEqual(t, len(inbox), 1)
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// storageDriver is what differs between the databases a world can be stored
+// in, the queries themselves are kept portable between them.
+type storageDriver struct {
+	name          string // database/sql driver name
+	migrationsDir string
+}
+
+var (
+	sqliteDriver   = storageDriver{name: "sqlite", migrationsDir: "migrations/sqlite"}
+	postgresDriver = storageDriver{name: "postgres", migrationsDir: "migrations/postgres"}
+)
+
+// storageDriverFromDSN picks the driver by the scheme of the data source name
+// and returns the name the driver itself expects. Plain paths and file: URIs
+// are SQLite databases, as every world was before there was a choice.
+func storageDriverFromDSN(dataSourceName string) (storageDriver, string, error) {
+	scheme, rest, ok := strings.Cut(dataSourceName, "://")
+	if !ok {
+		return sqliteDriver, dataSourceName, nil
+	}
+	switch scheme {
+	case "postgres", "postgresql":
+		return postgresDriver, dataSourceName, nil
+	case "sqlite":
+		return sqliteDriver, rest, nil
+	case "file":
+		return sqliteDriver, dataSourceName, nil
+	default:
+		return storageDriver{}, "", fmt.Errorf("unsupported storage scheme %s", scheme)
+	}
+}
+
+// jsonColumn scans a JSON column into its compact serialization, JSONB
+// columns come back with whitespace that a TEXT column would not have.
+type jsonColumn struct {
+	dst *string
+}
+
+func (c jsonColumn) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*c.dst = ""
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("unexpected json column type %T", src)
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, raw); err != nil {
+		// not every value stored before was valid json, keep it as is
+		*c.dst = string(raw)
+		return nil
+	}
+	*c.dst = compacted.String()
+	return nil
+}
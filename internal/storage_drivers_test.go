@@ -0,0 +1,85 @@
+package internal
+
+import "testing"
+
+func TestStorageDriverFromDSN(t *testing.T) {
+	testcases := []struct {
+		name               string
+		dataSourceName     string
+		wantDriver         storageDriver
+		wantDataSourceName string
+		wantErr            bool
+	}{
+		{
+			name:               "plain path",
+			dataSourceName:     "tmp/mockdb.sqlite3",
+			wantDriver:         sqliteDriver,
+			wantDataSourceName: "tmp/mockdb.sqlite3",
+		},
+		{
+			name:               "file uri",
+			dataSourceName:     "file:///tmp/mockdb.sqlite3?cache=shared",
+			wantDriver:         sqliteDriver,
+			wantDataSourceName: "file:///tmp/mockdb.sqlite3?cache=shared",
+		},
+		{
+			name:               "sqlite scheme",
+			dataSourceName:     "sqlite://tmp/mockdb.sqlite3",
+			wantDriver:         sqliteDriver,
+			wantDataSourceName: "tmp/mockdb.sqlite3",
+		},
+		{
+			name:               "postgres",
+			dataSourceName:     "postgres://stickerio@localhost:5432/world?sslmode=disable",
+			wantDriver:         postgresDriver,
+			wantDataSourceName: "postgres://stickerio@localhost:5432/world?sslmode=disable",
+		},
+		{
+			name:               "postgresql",
+			dataSourceName:     "postgresql://localhost/world",
+			wantDriver:         postgresDriver,
+			wantDataSourceName: "postgresql://localhost/world",
+		},
+		{
+			name:           "unsupported",
+			dataSourceName: "mysql://localhost/world",
+			wantErr:        true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			driver, dataSourceName, err := storageDriverFromDSN(testcase.dataSourceName)
+			if testcase.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", driver)
+				}
+				return
+			}
+			mustNoErr(t, err)
+			mustEqual(t, driver, testcase.wantDriver)
+			mustEqual(t, dataSourceName, testcase.wantDataSourceName)
+		})
+	}
+}
+
+func TestJSONColumn(t *testing.T) {
+	testcases := []struct {
+		name string
+		src  any
+		want string
+	}{
+		{name: "jsonb output", src: []byte(`{"wood": 1, "stone": 2}`), want: `{"wood":1,"stone":2}`},
+		{name: "text output", src: `{"wood":1}`, want: `{"wood":1}`},
+		{name: "null", src: nil, want: ""},
+		{name: "not json", src: "", want: ""},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got := "unset"
+			mustNoErr(t, jsonColumn{&got}.Scan(testcase.src))
+			mustEqual(t, got, testcase.want)
+		})
+	}
+}
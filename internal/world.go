@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// maxPathSamples bounds how many tiles of a path are looked at to work out its
+// movement cost, longer paths are sampled more sparsely.
+const maxPathSamples = 4096
+
+// A world tile is what the world generator placed on a location.
+type worldTile struct {
+	terrain        tTerrainName
+	resourceFields []tResourceName
+}
+
+// tileAt generates the tile at a location, the same seed always generates the
+// same world.
+func (cfg *Config) tileAt(x, y tCoordinate) worldTile {
+	tile := worldTile{terrain: cfg.terrainAt(x, y), resourceFields: make([]tResourceName, 0)}
+	for _, resourceName := range cfg.sortedResourceFields {
+		if cfg.isResourceField(resourceName, x, y) {
+			tile.resourceFields = append(tile.resourceFields, resourceName)
+		}
+	}
+	return tile
+}
+
+// Each terrain has its own noise layer, the first terrain by name to cover a
+// location wins it and the default terrain takes whatever is left.
+func (cfg *Config) terrainAt(x, y tCoordinate) tTerrainName {
+	for _, terrainName := range cfg.sortedTerrains {
+		if cfg.worldNoise("terrain/"+string(terrainName), x, y) >= 1-cfg.World.Terrains[terrainName].Frequency {
+			return terrainName
+		}
+	}
+	return cfg.World.DefaultTerrain
+}
+
+func (cfg *Config) isResourceField(resourceName tResourceName, x, y tCoordinate) bool {
+	field, ok := cfg.World.ResourceFields[resourceName]
+	return ok && cfg.worldNoise("resource/"+string(resourceName), x, y) >= 1-field.Frequency
+}
+
+// resourceFieldMultiplier is how much the fields at a location boost the
+// trickle of a resource.
+func (cfg *Config) resourceFieldMultiplier(resourceName tResourceName, x, y tCoordinate) float64 {
+	if !cfg.isResourceField(resourceName, x, y) {
+		return 1.0
+	}
+	return cfg.World.ResourceFields[resourceName].Multiplier
+}
+
+func (cfg *Config) withinWorld(x, y tCoordinate) bool {
+	size := cfg.World.Size
+	return size == 0 || (x >= -size && x <= size && y >= -size && y <= size)
+}
+
+// checkLocation tells whether a location can be settled or moved to: it has to
+// be within the world and not on impassable terrain. Whether it is occupied is
+// up to the caller.
+func (cfg *Config) checkLocation(x, y tCoordinate) error {
+	if !cfg.withinWorld(x, y) {
+		return fmt.Errorf("location %d,%d is outside of the world", x, y)
+	}
+	terrainName := cfg.terrainAt(x, y)
+	if cfg.World.Terrains[terrainName].Impassable {
+		return fmt.Errorf("location %d,%d is %s, which is impassable", x, y, terrainName)
+	}
+	return nil
+}
+
+// pathCost is the average movement cost of the tiles on the straight line
+// between two locations, leaving out the one departed from.
+func (cfg *Config) pathCost(x1, y1, x2, y2 tCoordinate, distance float64) float64 {
+	if len(cfg.World.Terrains) == 0 || distance <= 0 {
+		return 1.0
+	}
+	samples := int(math.Min(math.Ceil(distance), maxPathSamples))
+	totalCost := 0.0
+	for i := 1; i <= samples; i++ {
+		step := float64(i) / float64(samples)
+		x := tCoordinate(math.Round(float64(x1) + step*(float64(x2)-float64(x1))))
+		y := tCoordinate(math.Round(float64(y1) + step*(float64(y2)-float64(y1))))
+		totalCost += cfg.World.Terrains[cfg.terrainAt(x, y)].MovementCost
+	}
+	return totalCost / float64(samples)
+}
+
+// generateVillages lists the barbarian village locations of the whole world
+// row by row, only a bounded world can have them. Unlike terrain, villages are
+// scattered rather than in patches. It goes through every tile, see villagesOf.
+func (cfg *Config) generateVillages() []coordinates {
+	villages := make([]coordinates, 0)
+	size, frequency := cfg.World.Size, cfg.World.Barbarians.Frequency
+	if size <= 0 || frequency <= 0 {
+		return villages
+	}
+	villageSeed := cfg.layerSeed("village")
+	for y := -size; y <= size; y++ {
+		for x := -size; x <= size; x++ {
+			if latticeValue(villageSeed, int64(x), int64(y)) < frequency && cfg.checkLocation(x, y) == nil {
+				villages = append(villages, coordinates{x: x, y: y})
+			}
+		}
+	}
+	return villages
+}
+
+// villagesKey tells apart the worlds that have villages in different places:
+// it is everything generateVillages depends on.
+func (cfg *Config) villagesKey() string {
+	// NOTE: maps are printed sorted by key
+	return fmt.Sprintf("%d/%d/%d/%s/%v/%v", cfg.World.Seed, cfg.World.Size, cfg.World.FeatureSize,
+		cfg.World.DefaultTerrain, cfg.World.Terrains, cfg.World.Barbarians.Frequency)
+}
+
+// villageCityID is the same for a village on a location whichever config
+// version spawned it.
+func villageCityID(x, y tCoordinate) tCityID {
+	return tCityID(uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("village/%d/%d", x, y))).String())
+}
+
+// worldNoise is value noise in [0, 1): random values on a lattice spaced by the
+// feature size of the world, smoothly interpolated in between, so that terrain
+// comes in patches rather than scattered tiles. Layers are independent.
+func (cfg *Config) worldNoise(layer string, x, y tCoordinate) float64 {
+	layerSeed := cfg.layerSeed(layer)
+	size := int64(cfg.World.FeatureSize)
+	cellX, cellY := floorDiv(int64(x), size), floorDiv(int64(y), size)
+	fracX := smoothStep(float64(int64(x)-cellX*size) / float64(size))
+	fracY := smoothStep(float64(int64(y)-cellY*size) / float64(size))
+
+	top := lerp(latticeValue(layerSeed, cellX, cellY), latticeValue(layerSeed, cellX+1, cellY), fracX)
+	bottom := lerp(latticeValue(layerSeed, cellX, cellY+1), latticeValue(layerSeed, cellX+1, cellY+1), fracX)
+	return lerp(top, bottom, fracY)
+}
+
+func (cfg *Config) layerSeed(layer string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(layer))
+	return splitMix64(uint64(cfg.World.Seed) ^ h.Sum64())
+}
+
+func latticeValue(layerSeed uint64, x, y int64) float64 {
+	v := splitMix64(layerSeed + uint64(x)*0x9e3779b97f4a7c15)
+	v = splitMix64(v + uint64(y)*0xc2b2ae3d27d4eb4f)
+	// the top 53 bits fit a float64 mantissa
+	return float64(v>>11) / (1 << 53)
+}
+
+func splitMix64(v uint64) uint64 {
+	v += 0x9e3779b97f4a7c15
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+	return v ^ (v >> 31)
+}
+
+func floorDiv(a, b int64) int64 {
+	if a < 0 {
+		return (a+1)/b - 1
+	}
+	return a / b
+}
+
+func smoothStep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
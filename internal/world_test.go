@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestWorldConfig is the default config over a world of choice.
+func newTestWorldConfig(t *testing.T, world worldSpecs) *Config {
+	t.Helper()
+	c, err := parseGameConfig("../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.World = world
+	c.setDefaults()
+	err = c.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := newConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// writeTestWorldConfig writes the default config over a world of choice and
+// returns its path.
+func writeTestWorldConfig(t *testing.T, world worldSpecs) string {
+	t.Helper()
+	c, err := parseGameConfig("../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.World = world
+	rawConfig, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	err = os.WriteFile(path, rawConfig, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWorldIsSeeded(t *testing.T) {
+	world := worldSpecs{
+		Seed:           1,
+		FeatureSize:    4,
+		DefaultTerrain: "plains",
+		Terrains: map[tTerrainName]terrainSpecs{
+			"plains": {MovementCost: 1},
+			"forest": {Frequency: 0.5, MovementCost: 2},
+		},
+		ResourceFields: map[tResourceName]resourceFieldSpecs{
+			"sticks": {Frequency: 0.5, Multiplier: 2},
+		},
+	}
+	cfg := newTestWorldConfig(t, world)
+	sameCfg := newTestWorldConfig(t, world)
+	world.Seed = 2
+	otherCfg := newTestWorldConfig(t, world)
+
+	differs := false
+	for y := tCoordinate(-32); y < 32; y++ {
+		for x := tCoordinate(-32); x < 32; x++ {
+			mustEqual(t, cfg.tileAt(x, y), sameCfg.tileAt(x, y))
+			if cfg.terrainAt(x, y) != otherCfg.terrainAt(x, y) {
+				differs = true
+			}
+		}
+	}
+	mustEqual(t, differs, true)
+}
+
+func TestWorldNoiseRange(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 42, FeatureSize: 8})
+	for y := tCoordinate(-20); y < 20; y++ {
+		for x := tCoordinate(-20); x < 20; x++ {
+			v := cfg.worldNoise("terrain/forest", x, y)
+			if v < 0 || v >= 1 {
+				t.Fatalf("noise at %d,%d out of range: %f", x, y, v)
+			}
+		}
+	}
+}
+
+func TestCheckLocation(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1, Size: 10})
+	mustEqual(t, cfg.checkLocation(10, -10), nil)
+	if cfg.checkLocation(11, 0) == nil {
+		t.Fatal("expected a location outside of the world to be rejected")
+	}
+
+	// a terrain of frequency 1 covers the whole world
+	cfg = newTestWorldConfig(t, worldSpecs{
+		Seed:           1,
+		DefaultTerrain: "plains",
+		Terrains: map[tTerrainName]terrainSpecs{
+			"plains":    {MovementCost: 1},
+			"mountains": {Frequency: 1, MovementCost: 4, Impassable: true},
+		},
+	})
+	if cfg.checkLocation(0, 0) == nil {
+		t.Fatal("expected impassable terrain to be rejected")
+	}
+}
+
+func TestTravelTimeOverTerrain(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{Seed: 1})
+	mustEqual(t, cfg.travelTime(0, 0, 3, 4, 1), tSec(5))
+
+	cfg = newTestWorldConfig(t, worldSpecs{
+		Seed:           1,
+		DefaultTerrain: "plains",
+		Terrains: map[tTerrainName]terrainSpecs{
+			"plains": {MovementCost: 1},
+			"forest": {Frequency: 1, MovementCost: 2},
+		},
+	})
+	mustEqual(t, cfg.travelTime(0, 0, 3, 4, 1), tSec(10))
+	mustEqual(t, cfg.travelTime(0, 0, 0, 0, 1), tSec(0))
+}
+
+func TestResourceFieldsBoostTrickles(t *testing.T) {
+	cfg := newTestWorldConfig(t, worldSpecs{
+		Seed: 1,
+		ResourceFields: map[tResourceName]resourceFieldSpecs{
+			"sticks": {Frequency: 1, Multiplier: 2},
+		},
+	})
+	c := &city{buildingsLevel: make(tBuildingsLevel), resourceBase: make(tResourcesCount)}
+	err := reCityCalculateResources([]*Config{cfg}, 100, tResourcesCount{}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustEqual(t, c.resourceBase, tResourcesCount{"sticks": 400, "circles": 100})
+}
+
+func TestValidateWorld(t *testing.T) {
+	c, err := parseGameConfig("../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.World = worldSpecs{
+		Size:           -1,
+		FeatureSize:    1,
+		DefaultTerrain: "swamp",
+		Terrains: map[tTerrainName]terrainSpecs{
+			"plains": {Frequency: 2},
+		},
+		ResourceFields: map[tResourceName]resourceFieldSpecs{
+			"gold": {Frequency: 0.1, Multiplier: 2},
+		},
+	}
+	err = c.Validate()
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("got %v, want %v", err, errInvalidConfig)
+	}
+	mustEqual(t, err.Error(), `invalid config:
+  - resource field gold: unknown resource
+  - terrain plains: frequency must be between 0 and 1
+  - terrain plains: movementCost must be positive
+  - world: size must not be negative
+  - world: unknown default terrain swamp`)
+}
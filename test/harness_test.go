@@ -0,0 +1,259 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/go-cmp/cmp"
+	api "github.com/luisferreira32/stickerio/api"
+	"github.com/luisferreira32/stickerio/internal"
+)
+
+const (
+	testWorldID = "e2e"
+	// the fake clock starts at an arbitrary epoch, far from zero to catch unset epochs
+	testStartEpoch = 1_000_000
+)
+
+// testWorld is a whole server in-process: the real router over a temporary
+// SQLite database, with a fake clock so that scenarios control the game time.
+type testWorld struct {
+	server       *httptest.Server
+	clock        *internal.FakeClock
+	eventSourcer *internal.EventSourcer
+}
+
+func newTestWorld(t *testing.T) *testWorld {
+	t.Helper()
+	ctx := context.Background()
+
+	repository := internal.NewStickerioRepository(filepath.Join(t.TempDir(), "stickerio.db"))
+	_, err := repository.MigrateUp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := internal.NewFakeClock(time.Unix(testStartEpoch, 0))
+	configs, err := internal.NewConfigHistory(ctx, repository, clock, "../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventSourcer := internal.NewEventSourcer(repository, configs, clock)
+	handlers := internal.NewServerHandler(repository, eventSourcer, configs, clock)
+	router := internal.NewRouter(testWorldID, map[string]func(chi.Router){
+		testWorldID: internal.WorldRoutes(handlers, ""),
+	})
+
+	w := &testWorld{
+		server:       httptest.NewServer(router),
+		clock:        clock,
+		eventSourcer: eventSourcer,
+	}
+	t.Cleanup(w.server.Close)
+	return w
+}
+
+// do sends a request to the world API and fails the test on an unexpected status.
+func (w *testWorld) do(t *testing.T, method, path string, body any, wantStatus int) []byte {
+	t.Helper()
+	var reqBody io.Reader
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reqBody = bytes.NewReader(rawBody)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/%s%s", w.server.URL, internal.APIVersion, path), reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := w.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s: got status %d, want %d: %s", method, path, resp.StatusCode, wantStatus, respBody)
+	}
+	return respBody
+}
+
+// command sends a game command and processes it right away.
+func (w *testWorld) command(t *testing.T, method, path string, body any) {
+	t.Helper()
+	w.do(t, method, path, body, http.StatusAccepted)
+	w.sync(t)
+}
+
+func (w *testWorld) sync(t *testing.T) {
+	t.Helper()
+	err := w.eventSourcer.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (w *testWorld) getCity(t *testing.T, cityID string) api.V1City {
+	t.Helper()
+	c := api.V1City{}
+	err := json.Unmarshal(w.do(t, http.MethodGet, "/cities/"+cityID, nil, http.StatusOK), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// A step is an action or an assertion of a scenario, they run in order
+// against the same world.
+type step func(t *testing.T, w *testWorld)
+
+func createCity(cityID string, x, y int32) step {
+	return func(t *testing.T, w *testWorld) {
+		w.command(t, http.MethodPost, "/cities", api.V1CityInfo{Id: cityID, Name: cityID, LocationX: x, LocationY: y})
+	}
+}
+
+// rejectCity expects the city not to be created, e.g. on an unsettleable location.
+func rejectCity(cityID string, x, y int32) step {
+	return func(t *testing.T, w *testWorld) {
+		w.do(t, http.MethodPost, "/cities", api.V1CityInfo{Id: cityID, Name: cityID, LocationX: x, LocationY: y}, http.StatusBadRequest)
+	}
+}
+
+// wait moves the game time forward, processing every event due meanwhile.
+func wait(seconds int) step {
+	return func(t *testing.T, w *testWorld) {
+		w.clock.Advance(time.Duration(seconds) * time.Second)
+		w.sync(t)
+	}
+}
+
+func queueBuilding(cityID, itemID, building string, level int64) step {
+	return func(t *testing.T, w *testWorld) {
+		w.command(t, http.MethodPost, fmt.Sprintf("/cities/%s/buildingqitems", cityID), api.V1BuildingQueueItem{Id: itemID, Building: building, Level: level})
+	}
+}
+
+func queueUnits(cityID, itemID, unitType string, count int64) step {
+	return func(t *testing.T, w *testWorld) {
+		w.command(t, http.MethodPost, fmt.Sprintf("/cities/%s/unitqitems", cityID), api.V1UnitQueueItem{Id: itemID, UnitType: unitType, UnitCount: count})
+	}
+}
+
+// startMovement sends units from one city to another, at the location of the latter.
+func startMovement(movementID, originID, destinationID string, units map[string]int64) step {
+	return func(t *testing.T, w *testWorld) {
+		destination := api.V1CityInfo{}
+		err := json.Unmarshal(w.do(t, http.MethodGet, fmt.Sprintf("/cities/%s/info", destinationID), nil, http.StatusOK), &destination)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.command(t, http.MethodPost, "/movements", api.V1Movement{
+			Id:            movementID,
+			OriginID:      originID,
+			DestinationID: destinationID,
+			DestinationX:  destination.LocationX,
+			DestinationY:  destination.LocationY,
+			UnitCount:     units,
+			ResourceCount: map[string]int64{},
+		})
+	}
+}
+
+// cityState is the expected state of a city, only the set fields are checked.
+type cityState struct {
+	buildings map[string]int64
+	units     map[string]int64
+	resources map[string]int64
+}
+
+func expectCity(cityID string, want cityState) step {
+	return func(t *testing.T, w *testWorld) {
+		t.Helper()
+		got := w.getCity(t, cityID)
+		for _, check := range []struct {
+			name      string
+			got, want map[string]int64
+		}{
+			{"buildings", got.Buildings, want.buildings},
+			{"units", got.UnitCount, want.units},
+			{"resources", got.CityResources.BaseCount, want.resources},
+		} {
+			if check.want == nil {
+				continue
+			}
+			if diff := cmp.Diff(check.want, check.got); diff != "" {
+				t.Errorf("unexpected %s in city %s (-want +got):\n%s", check.name, cityID, diff)
+			}
+		}
+	}
+}
+
+// expectMovements checks the IDs of the ongoing movements of the player.
+func expectMovements(movementIDs ...string) step {
+	return func(t *testing.T, w *testWorld) {
+		t.Helper()
+		movements := make([]api.V1Movement, 0)
+		err := json.Unmarshal(w.do(t, http.MethodGet, "/movements", nil, http.StatusOK), &movements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]string, len(movements))
+		for i, m := range movements {
+			got[i] = m.Id
+		}
+		sort.Strings(movementIDs)
+		if diff := cmp.Diff(append([]string{}, movementIDs...), got); diff != "" {
+			t.Errorf("unexpected movements (-want +got):\n%s", diff)
+		}
+	}
+}
+
+// expectCities lists the cities with the query, e.g. "near=0,0", and expects
+// them in that very order.
+func expectCities(query string, cityIDs ...string) step {
+	return func(t *testing.T, w *testWorld) {
+		t.Helper()
+		cities := make([]api.V1CityInfo, 0)
+		err := json.Unmarshal(w.do(t, http.MethodGet, "/cities?"+query, nil, http.StatusOK), &cities)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]string, len(cities))
+		for i, c := range cities {
+			got[i] = c.Id
+		}
+		if diff := cmp.Diff(append([]string{}, cityIDs...), got); diff != "" {
+			t.Errorf("unexpected cities for %s (-want +got):\n%s", query, diff)
+		}
+	}
+}
+
+// expectMap gets the map with the query, e.g. "x1=0&y1=0&x2=10&y2=10".
+func expectMap(query string, want api.V1Map) step {
+	return func(t *testing.T, w *testWorld) {
+		t.Helper()
+		got := api.V1Map{}
+		err := json.Unmarshal(w.do(t, http.MethodGet, "/map?"+query, nil, http.StatusOK), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected map for %s (-want +got):\n%s", query, diff)
+		}
+	}
+}